@@ -7,13 +7,14 @@ require (
 	github.com/gogo/protobuf v1.3.2
 	github.com/golang/protobuf v1.4.3
 	github.com/jinzhu/gorm v1.9.1
-	github.com/jinzhu/inflection v0.0.0-20180308033659-04140366298a
-	github.com/jinzhu/now v1.1.1 // indirect
+	github.com/jinzhu/inflection v1.0.0
 	github.com/lib/pq v0.0.0-20180523175426-90697d60dd84
 	github.com/lyft/protoc-gen-star v0.5.2
 	github.com/mattn/go-sqlite3 v1.14.6 // indirect
-	github.com/wk8/go-ordered-map v0.2.0
-	google.golang.org/protobuf v1.23.0
+	google.golang.org/grpc v1.43.0
+	google.golang.org/protobuf v1.25.0
+	gorm.io/driver/sqlite v1.1.4
+	gorm.io/gorm v1.21.15
 )
 
 go 1.13
@@ -0,0 +1,31 @@
+package gorm
+
+import (
+	"context"
+
+	gormio "gorm.io/gorm"
+)
+
+// includeDeletedKey is the key telling in `context.Context` whether the
+// default handlers see the soft deleted rows.
+var includeDeletedKey ctxKey = 1
+
+// IncludeDeleted returns a new Context making the DefaultRead, DefaultUpdate
+// and DefaultList handlers of the soft deleted types see the deleted rows too.
+func IncludeDeleted(parent context.Context) context.Context {
+	return context.WithValue(parent, includeDeletedKey, true)
+}
+
+// IncludeDeletedFromContext tells whether ctx includes the soft deleted rows.
+func IncludeDeletedFromContext(ctx context.Context) bool {
+	include, _ := ctx.Value(includeDeletedKey).(bool)
+	return include
+}
+
+// ScopeDeleted returns db unscoped if ctx includes the soft deleted rows.
+func ScopeDeleted(ctx context.Context, db *gormio.DB) *gormio.DB {
+	if IncludeDeletedFromContext(ctx) {
+		return db.Unscoped()
+	}
+	return db
+}
@@ -0,0 +1,45 @@
+package gorm
+
+import (
+	"context"
+	"testing"
+
+	gormio "gorm.io/gorm"
+)
+
+type testDeletedRecord struct {
+	ID        uint
+	DeletedAt gormio.DeletedAt
+}
+
+func TestIncludeDeleted(t *testing.T) {
+	ctx := context.Background()
+	if IncludeDeletedFromContext(ctx) {
+		t.Error("Expected deleted rows to be excluded by default")
+	}
+	if !IncludeDeletedFromContext(IncludeDeleted(ctx)) {
+		t.Error("Expected deleted rows to be included")
+	}
+}
+
+func TestScopeDeleted(t *testing.T) {
+	db := openTestDB(t)
+	if err := db.AutoMigrate(&testDeletedRecord{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Create(&testDeletedRecord{ID: 1}).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Delete(&testDeletedRecord{ID: 1}).Error; err != nil {
+		t.Fatal(err)
+	}
+	var count int64
+	ScopeDeleted(context.Background(), db).Model(&testDeletedRecord{}).Count(&count)
+	if count != 0 {
+		t.Errorf("Expected no record, got %d", count)
+	}
+	ScopeDeleted(IncludeDeleted(context.Background()), db).Model(&testDeletedRecord{}).Count(&count)
+	if count != 1 {
+		t.Errorf("Expected the deleted record, got %d", count)
+	}
+}
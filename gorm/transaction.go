@@ -0,0 +1,123 @@
+package gorm
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/grpc"
+	gormio "gorm.io/gorm"
+
+	"github.com/TheSDTM/protoc-gen-gorm/errors"
+)
+
+// ctxKey is an unexported type for keys defined in this package.
+// This prevents collisions with keys defined in other packages.
+type ctxKey int
+
+// txnKey is the key for `*Transaction` values in `context.Context`.
+// It is unexported; clients use NewContext and FromContext
+// instead of using this key directly.
+var txnKey ctxKey
+
+// NewContext returns a new Context that carries value txn.
+func NewContext(parent context.Context, txn *Transaction) context.Context {
+	return context.WithValue(parent, txnKey, txn)
+}
+
+// FromContext returns the *Transaction value stored in ctx, if any.
+func FromContext(ctx context.Context) (txn *Transaction, ok bool) {
+	txn, ok = ctx.Value(txnKey).(*Transaction)
+	return
+}
+
+// BeginFromContext will extract transaction wrapper from context and start new transaction,
+// errors.NoTransactionError is returned if there is no transaction in the context.
+func BeginFromContext(ctx context.Context) (*gormio.DB, error) {
+	txn, ok := FromContext(ctx)
+	if !ok {
+		return nil, errors.NoTransactionError
+	}
+	db := txn.Begin()
+	if db.Error != nil {
+		return nil, db.Error
+	}
+	return db, nil
+}
+
+// Transaction serves as a wrapper around a *gorm.DB instance.
+// The DB transaction is only opened by the first Begin call, so requests
+// which never touch the database do not open one.
+type Transaction struct {
+	mu      sync.Mutex
+	parent  *gormio.DB
+	current *gormio.DB
+}
+
+// NewTransaction returns a new Transaction on top of db.
+func NewTransaction(db *gormio.DB) *Transaction {
+	return &Transaction{parent: db}
+}
+
+// Begin starts the transaction if it has not been started yet and returns
+// the *gorm.DB bound to it. A failed start is returned with its error but not
+// kept, so the next call tries again.
+func (t *Transaction) Begin() *gormio.DB {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.current == nil {
+		db := t.parent.Begin()
+		if db.Error != nil {
+			return db
+		}
+		t.current = db
+	}
+	return t.current
+}
+
+// Commit commits the transaction, it is a no-op if it was never started.
+func (t *Transaction) Commit() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.current == nil {
+		return nil
+	}
+	err := t.current.Commit().Error
+	t.current = nil
+	return err
+}
+
+// Rollback rolls the transaction back, it is a no-op if it was never started.
+func (t *Transaction) Rollback() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.current == nil {
+		return nil
+	}
+	err := t.current.Rollback().Error
+	t.current = nil
+	return err
+}
+
+// UnaryServerInterceptor returns grpc.UnaryServerInterceptor that puts a
+// Transaction on top of db into the context of every request. The transaction
+// is committed if the handler succeeds and rolled back if it fails or panics.
+func UnaryServerInterceptor(db *gormio.DB) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		txn := NewTransaction(db)
+		defer func() {
+			if r := recover(); r != nil {
+				txn.Rollback()
+				panic(r)
+			}
+		}()
+		resp, err = handler(NewContext(ctx, txn), req)
+		if err != nil {
+			txn.Rollback()
+			return nil, err
+		}
+		if err = txn.Commit(); err != nil {
+			return nil, err
+		}
+		return resp, nil
+	}
+}
@@ -0,0 +1,120 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc"
+	"gorm.io/driver/sqlite"
+	gormio "gorm.io/gorm"
+
+	gerrors "github.com/TheSDTM/protoc-gen-gorm/errors"
+)
+
+type testRecord struct {
+	ID   uint
+	Name string
+}
+
+func openTestDB(t *testing.T) *gormio.DB {
+	db, err := gormio.Open(sqlite.Open("file::memory:"), &gormio.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(&testRecord{}); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestBeginFromContext(t *testing.T) {
+	if _, err := BeginFromContext(context.Background()); err != gerrors.NoTransactionError {
+		t.Errorf("Expected %v, got %v", gerrors.NoTransactionError, err)
+	}
+	db := openTestDB(t)
+	txn := NewTransaction(db)
+	ctx := NewContext(context.Background(), txn)
+	if got, ok := FromContext(ctx); !ok || got != txn {
+		t.Errorf("Expected transaction %v in context, got %v", txn, got)
+	}
+	first, err := BeginFromContext(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := BeginFromContext(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first != second {
+		t.Error("Expected the transaction to be started only once")
+	}
+	if err = txn.Rollback(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestBeginFailure(t *testing.T) {
+	closed := openTestDB(t)
+	sqlDB, err := closed.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = sqlDB.Close(); err != nil {
+		t.Fatal(err)
+	}
+	txn := NewTransaction(closed)
+	if _, err = BeginFromContext(NewContext(context.Background(), txn)); err == nil {
+		t.Fatal("Expected error but didn't get any")
+	}
+	if txn.current != nil {
+		t.Error("Expected the failed transaction not to be kept")
+	}
+	if err = txn.Commit(); err != nil {
+		t.Errorf("Expected nothing to commit, got %v", err)
+	}
+
+	txn.parent = openTestDB(t)
+	db := txn.Begin()
+	if db.Error != nil {
+		t.Fatalf("Expected the transaction to start again, got %v", db.Error)
+	}
+	if err = txn.Rollback(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	db := openTestDB(t)
+	interceptor := UnaryServerInterceptor(db)
+	info := &grpc.UnaryServerInfo{FullMethod: "/test/Create"}
+
+	create := func(name string, err error) grpc.UnaryHandler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			txn, e := BeginFromContext(ctx)
+			if e != nil {
+				return nil, e
+			}
+			if e = txn.Create(&testRecord{Name: name}).Error; e != nil {
+				return nil, e
+			}
+			return name, err
+		}
+	}
+
+	if _, err := interceptor(context.Background(), nil, info, create("committed", nil)); err != nil {
+		t.Fatal(err)
+	}
+	failure := errors.New("handler failed")
+	if _, err := interceptor(context.Background(), nil, info, create("rolled back", failure)); err != failure {
+		t.Errorf("Expected %v, got %v", failure, err)
+	}
+
+	var records []testRecord
+	if err := db.Find(&records).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || records[0].Name != "committed" {
+		t.Errorf("Expected only the committed record, got %+v", records)
+	}
+}
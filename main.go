@@ -1,15 +1,27 @@
 package main
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/TheSDTM/protoc-gen-gorm/plugin"
 
 	pgs "github.com/lyft/protoc-gen-star"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "diff" {
+		if err := plugin.RunDiff(os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+	output := plugin.FeatureWriter{Writer: os.Stdout}
 	plugin := &plugin.OrmPlugin{ModuleBase: &pgs.ModuleBase{}}
 	pgs.Init(
 		pgs.DebugEnv("DEBUG"),
+		pgs.ProtocOutput(output),
 	).RegisterModule(
 		plugin,
 	).RegisterPostProcessor(
@@ -26,6 +26,248 @@ const (
 // of the legacy proto package is being used.
 const _ = proto.ProtoPackageIsVersion4
 
+type NameCase int32
+
+const (
+	// lower snake case, e.g. user_infos
+	NameCase_SNAKE_CASE NameCase = 1
+	// lower camel case, e.g. userInfos
+	NameCase_CAMEL_CASE NameCase = 2
+)
+
+// Enum value maps for NameCase.
+var (
+	NameCase_name = map[int32]string{
+		1: "SNAKE_CASE",
+		2: "CAMEL_CASE",
+	}
+	NameCase_value = map[string]int32{
+		"SNAKE_CASE": 1,
+		"CAMEL_CASE": 2,
+	}
+)
+
+func (x NameCase) Enum() *NameCase {
+	p := new(NameCase)
+	*p = x
+	return p
+}
+
+func (x NameCase) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (NameCase) Descriptor() protoreflect.EnumDescriptor {
+	return file_options_gorm_proto_enumTypes[0].Descriptor()
+}
+
+func (NameCase) Type() protoreflect.EnumType {
+	return &file_options_gorm_proto_enumTypes[0]
+}
+
+func (x NameCase) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Do not use.
+func (x *NameCase) UnmarshalJSON(b []byte) error {
+	num, err := protoimpl.X.UnmarshalJSONEnum(x.Descriptor(), b)
+	if err != nil {
+		return err
+	}
+	*x = NameCase(num)
+	return nil
+}
+
+// Deprecated: Use NameCase.Descriptor instead.
+func (NameCase) EnumDescriptor() ([]byte, []int) {
+	return file_options_gorm_proto_rawDescGZIP(), []int{0}
+}
+
+type SoftDeleteMode int32
+
+const (
+	// nullable timestamp of the deletion, a gorm.DeletedAt
+	SoftDeleteMode_DELETED_AT SoftDeleteMode = 1
+	// unix time of the deletion in seconds, zero for the rows not deleted
+	SoftDeleteMode_UNIX_TIMESTAMP SoftDeleteMode = 2
+	// 1 for the deleted rows, zero for the others
+	SoftDeleteMode_FLAG SoftDeleteMode = 3
+)
+
+// Enum value maps for SoftDeleteMode.
+var (
+	SoftDeleteMode_name = map[int32]string{
+		1: "DELETED_AT",
+		2: "UNIX_TIMESTAMP",
+		3: "FLAG",
+	}
+	SoftDeleteMode_value = map[string]int32{
+		"DELETED_AT":     1,
+		"UNIX_TIMESTAMP": 2,
+		"FLAG":           3,
+	}
+)
+
+func (x SoftDeleteMode) Enum() *SoftDeleteMode {
+	p := new(SoftDeleteMode)
+	*p = x
+	return p
+}
+
+func (x SoftDeleteMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SoftDeleteMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_options_gorm_proto_enumTypes[1].Descriptor()
+}
+
+func (SoftDeleteMode) Type() protoreflect.EnumType {
+	return &file_options_gorm_proto_enumTypes[1]
+}
+
+func (x SoftDeleteMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Do not use.
+func (x *SoftDeleteMode) UnmarshalJSON(b []byte) error {
+	num, err := protoimpl.X.UnmarshalJSONEnum(x.Descriptor(), b)
+	if err != nil {
+		return err
+	}
+	*x = SoftDeleteMode(num)
+	return nil
+}
+
+// Deprecated: Use SoftDeleteMode.Descriptor instead.
+func (SoftDeleteMode) EnumDescriptor() ([]byte, []int) {
+	return file_options_gorm_proto_rawDescGZIP(), []int{1}
+}
+
+type SerializeFormat int32
+
+const (
+	// protojson encoded document, in the JSON column type of the engine
+	SerializeFormat_JSON SerializeFormat = 1
+	// proto binary encoding, in the binary column type of the engine
+	SerializeFormat_PROTO_BINARY SerializeFormat = 2
+)
+
+// Enum value maps for SerializeFormat.
+var (
+	SerializeFormat_name = map[int32]string{
+		1: "JSON",
+		2: "PROTO_BINARY",
+	}
+	SerializeFormat_value = map[string]int32{
+		"JSON":         1,
+		"PROTO_BINARY": 2,
+	}
+)
+
+func (x SerializeFormat) Enum() *SerializeFormat {
+	p := new(SerializeFormat)
+	*p = x
+	return p
+}
+
+func (x SerializeFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SerializeFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_options_gorm_proto_enumTypes[2].Descriptor()
+}
+
+func (SerializeFormat) Type() protoreflect.EnumType {
+	return &file_options_gorm_proto_enumTypes[2]
+}
+
+func (x SerializeFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Do not use.
+func (x *SerializeFormat) UnmarshalJSON(b []byte) error {
+	num, err := protoimpl.X.UnmarshalJSONEnum(x.Descriptor(), b)
+	if err != nil {
+		return err
+	}
+	*x = SerializeFormat(num)
+	return nil
+}
+
+// Deprecated: Use SerializeFormat.Descriptor instead.
+func (SerializeFormat) EnumDescriptor() ([]byte, []int) {
+	return file_options_gorm_proto_rawDescGZIP(), []int{2}
+}
+
+type EnumStorage int32
+
+const (
+	// int32 column holding the enum number
+	EnumStorage_INT EnumStorage = 1
+	// string column holding the value name
+	EnumStorage_STRING EnumStorage = 2
+	// enum type of the engine holding the value name, a Postgres or
+	// CockroachDB type created with CREATE TYPE or an inline MySQL ENUM. Other
+	// engines fall back to STRING.
+	EnumStorage_NATIVE EnumStorage = 3
+)
+
+// Enum value maps for EnumStorage.
+var (
+	EnumStorage_name = map[int32]string{
+		1: "INT",
+		2: "STRING",
+		3: "NATIVE",
+	}
+	EnumStorage_value = map[string]int32{
+		"INT":    1,
+		"STRING": 2,
+		"NATIVE": 3,
+	}
+)
+
+func (x EnumStorage) Enum() *EnumStorage {
+	p := new(EnumStorage)
+	*p = x
+	return p
+}
+
+func (x EnumStorage) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EnumStorage) Descriptor() protoreflect.EnumDescriptor {
+	return file_options_gorm_proto_enumTypes[3].Descriptor()
+}
+
+func (EnumStorage) Type() protoreflect.EnumType {
+	return &file_options_gorm_proto_enumTypes[3]
+}
+
+func (x EnumStorage) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Do not use.
+func (x *EnumStorage) UnmarshalJSON(b []byte) error {
+	num, err := protoimpl.X.UnmarshalJSONEnum(x.Descriptor(), b)
+	if err != nil {
+		return err
+	}
+	*x = EnumStorage(num)
+	return nil
+}
+
+// Deprecated: Use EnumStorage.Descriptor instead.
+func (EnumStorage) EnumDescriptor() ([]byte, []int) {
+	return file_options_gorm_proto_rawDescGZIP(), []int{3}
+}
+
 type FieldWritePermission int32
 
 const (
@@ -62,11 +304,11 @@ func (x FieldWritePermission) String() string {
 }
 
 func (FieldWritePermission) Descriptor() protoreflect.EnumDescriptor {
-	return file_options_gorm_proto_enumTypes[0].Descriptor()
+	return file_options_gorm_proto_enumTypes[4].Descriptor()
 }
 
 func (FieldWritePermission) Type() protoreflect.EnumType {
-	return &file_options_gorm_proto_enumTypes[0]
+	return &file_options_gorm_proto_enumTypes[4]
 }
 
 func (x FieldWritePermission) Number() protoreflect.EnumNumber {
@@ -85,13 +327,28 @@ func (x *FieldWritePermission) UnmarshalJSON(b []byte) error {
 
 // Deprecated: Use FieldWritePermission.Descriptor instead.
 func (FieldWritePermission) EnumDescriptor() ([]byte, []int) {
-	return file_options_gorm_proto_rawDescGZIP(), []int{0}
+	return file_options_gorm_proto_rawDescGZIP(), []int{4}
 }
 
 type GormFileOptions struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	// naming of the tables of the ormable messages of the file, the table
+	// message option overrides it
+	Naming *NamingStrategy `protobuf:"bytes,1,opt,name=naming" json:"naming,omitempty"`
+	// ormable_by_default makes every message of the file ormable unless its
+	// ormable option is false
+	OrmableByDefault *bool `protobuf:"varint,2,opt,name=ormable_by_default,json=ormableByDefault" json:"ormable_by_default,omitempty"`
+	// engine of the file, overrides the engine plugin parameter
+	Engine *string `protobuf:"bytes,3,opt,name=engine" json:"engine,omitempty"`
+	// enum_storage of the enum fields of the file, overrides the enums plugin
+	// parameter and is overridden by the enum and field options
+	EnumStorage *EnumStorage `protobuf:"varint,4,opt,name=enum_storage,json=enumStorage,enum=gorm.EnumStorage" json:"enum_storage,omitempty"`
+	// default_tags are given to the fields of the ormable messages of the file
+	// named like them, the tag set on a field overrides them
+	DefaultTags []*DefaultTag `protobuf:"bytes,5,rep,name=default_tags,json=defaultTags" json:"default_tags,omitempty"`
 }
 
 func (x *GormFileOptions) Reset() {
@@ -126,20 +383,208 @@ func (*GormFileOptions) Descriptor() ([]byte, []int) {
 	return file_options_gorm_proto_rawDescGZIP(), []int{0}
 }
 
+func (x *GormFileOptions) GetNaming() *NamingStrategy {
+	if x != nil {
+		return x.Naming
+	}
+	return nil
+}
+
+func (x *GormFileOptions) GetOrmableByDefault() bool {
+	if x != nil && x.OrmableByDefault != nil {
+		return *x.OrmableByDefault
+	}
+	return false
+}
+
+func (x *GormFileOptions) GetEngine() string {
+	if x != nil && x.Engine != nil {
+		return *x.Engine
+	}
+	return ""
+}
+
+func (x *GormFileOptions) GetEnumStorage() EnumStorage {
+	if x != nil && x.EnumStorage != nil {
+		return *x.EnumStorage
+	}
+	return EnumStorage_INT
+}
+
+func (x *GormFileOptions) GetDefaultTags() []*DefaultTag {
+	if x != nil {
+		return x.DefaultTags
+	}
+	return nil
+}
+
+type DefaultTag struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// field is the proto name of the fields, e.g. created_at
+	Field *string  `protobuf:"bytes,1,req,name=field" json:"field,omitempty"`
+	Tag   *GormTag `protobuf:"bytes,2,opt,name=tag" json:"tag,omitempty"`
+}
+
+func (x *DefaultTag) Reset() {
+	*x = DefaultTag{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_options_gorm_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DefaultTag) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DefaultTag) ProtoMessage() {}
+
+func (x *DefaultTag) ProtoReflect() protoreflect.Message {
+	mi := &file_options_gorm_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DefaultTag.ProtoReflect.Descriptor instead.
+func (*DefaultTag) Descriptor() ([]byte, []int) {
+	return file_options_gorm_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DefaultTag) GetField() string {
+	if x != nil && x.Field != nil {
+		return *x.Field
+	}
+	return ""
+}
+
+func (x *DefaultTag) GetTag() *GormTag {
+	if x != nil {
+		return x.Tag
+	}
+	return nil
+}
+
+type NamingStrategy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// case of the table names, SNAKE_CASE by default
+	Case *NameCase `protobuf:"varint,1,opt,name=case,enum=gorm.NameCase" json:"case,omitempty"`
+	// singular table names, e.g. user instead of users
+	Singular *bool `protobuf:"varint,2,opt,name=singular" json:"singular,omitempty"`
+	// table_prefix is prepended to the table names
+	TablePrefix *string `protobuf:"bytes,3,opt,name=table_prefix,json=tablePrefix" json:"table_prefix,omitempty"`
+	// schema qualifies the table names, e.g. app.users
+	Schema *string `protobuf:"bytes,4,opt,name=schema" json:"schema,omitempty"`
+	// case of the column names, SNAKE_CASE by default, the column tag
+	// overrides it
+	ColumnCase *NameCase `protobuf:"varint,5,opt,name=column_case,json=columnCase,enum=gorm.NameCase" json:"column_case,omitempty"`
+}
+
+func (x *NamingStrategy) Reset() {
+	*x = NamingStrategy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_options_gorm_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NamingStrategy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NamingStrategy) ProtoMessage() {}
+
+func (x *NamingStrategy) ProtoReflect() protoreflect.Message {
+	mi := &file_options_gorm_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NamingStrategy.ProtoReflect.Descriptor instead.
+func (*NamingStrategy) Descriptor() ([]byte, []int) {
+	return file_options_gorm_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *NamingStrategy) GetCase() NameCase {
+	if x != nil && x.Case != nil {
+		return *x.Case
+	}
+	return NameCase_SNAKE_CASE
+}
+
+func (x *NamingStrategy) GetSingular() bool {
+	if x != nil && x.Singular != nil {
+		return *x.Singular
+	}
+	return false
+}
+
+func (x *NamingStrategy) GetTablePrefix() string {
+	if x != nil && x.TablePrefix != nil {
+		return *x.TablePrefix
+	}
+	return ""
+}
+
+func (x *NamingStrategy) GetSchema() string {
+	if x != nil && x.Schema != nil {
+		return *x.Schema
+	}
+	return ""
+}
+
+func (x *NamingStrategy) GetColumnCase() NameCase {
+	if x != nil && x.ColumnCase != nil {
+		return *x.ColumnCase
+	}
+	return NameCase_SNAKE_CASE
+}
+
 type GormMessageOptions struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Ormable *bool         `protobuf:"varint,1,req,name=ormable" json:"ormable,omitempty"`
+	Ormable *bool         `protobuf:"varint,1,opt,name=ormable" json:"ormable,omitempty"`
 	Include []*ExtraField `protobuf:"bytes,2,rep,name=include" json:"include,omitempty"`
-	Table   *string       `protobuf:"bytes,3,opt,name=table" json:"table,omitempty"`
+	// table of the message, used as is instead of the name given by the naming
+	// strategy of the file
+	Table *string `protobuf:"bytes,3,opt,name=table" json:"table,omitempty"`
+	// indexes of the table, they may cover several fields and set what the
+	// index tag of a field cannot
+	Indexes []*IndexDefinition `protobuf:"bytes,4,rep,name=indexes" json:"indexes,omitempty"`
+	// soft_delete keeps the deleted rows, marked as deleted in a column the
+	// read and list handlers filter them out with
+	SoftDelete *bool `protobuf:"varint,5,opt,name=soft_delete,json=softDelete" json:"soft_delete,omitempty"`
+	// soft_delete_column of the mark, deleted_at by default
+	SoftDeleteColumn *string `protobuf:"bytes,6,opt,name=soft_delete_column,json=softDeleteColumn" json:"soft_delete_column,omitempty"`
+	// soft_delete_mode of the mark, DELETED_AT by default
+	SoftDeleteMode *SoftDeleteMode `protobuf:"varint,7,opt,name=soft_delete_mode,json=softDeleteMode,enum=gorm.SoftDeleteMode" json:"soft_delete_mode,omitempty"`
 }
 
 func (x *GormMessageOptions) Reset() {
 	*x = GormMessageOptions{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_options_gorm_proto_msgTypes[1]
+		mi := &file_options_gorm_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -152,7 +597,7 @@ func (x *GormMessageOptions) String() string {
 func (*GormMessageOptions) ProtoMessage() {}
 
 func (x *GormMessageOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_options_gorm_proto_msgTypes[1]
+	mi := &file_options_gorm_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -165,7 +610,7 @@ func (x *GormMessageOptions) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GormMessageOptions.ProtoReflect.Descriptor instead.
 func (*GormMessageOptions) Descriptor() ([]byte, []int) {
-	return file_options_gorm_proto_rawDescGZIP(), []int{1}
+	return file_options_gorm_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *GormMessageOptions) GetOrmable() bool {
@@ -189,6 +634,194 @@ func (x *GormMessageOptions) GetTable() string {
 	return ""
 }
 
+func (x *GormMessageOptions) GetIndexes() []*IndexDefinition {
+	if x != nil {
+		return x.Indexes
+	}
+	return nil
+}
+
+func (x *GormMessageOptions) GetSoftDelete() bool {
+	if x != nil && x.SoftDelete != nil {
+		return *x.SoftDelete
+	}
+	return false
+}
+
+func (x *GormMessageOptions) GetSoftDeleteColumn() string {
+	if x != nil && x.SoftDeleteColumn != nil {
+		return *x.SoftDeleteColumn
+	}
+	return ""
+}
+
+func (x *GormMessageOptions) GetSoftDeleteMode() SoftDeleteMode {
+	if x != nil && x.SoftDeleteMode != nil {
+		return *x.SoftDeleteMode
+	}
+	return SoftDeleteMode_DELETED_AT
+}
+
+type IndexDefinition struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// name of the index, idx_<table>_<columns> by default
+	Name *string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// columns of the index in order
+	Columns []*IndexColumn `protobuf:"bytes,2,rep,name=columns" json:"columns,omitempty"`
+	Unique  *bool          `protobuf:"varint,3,opt,name=unique" json:"unique,omitempty"`
+	// type of the index, e.g. btree, gin or brin on Postgres, btree or hash on
+	// MySQL
+	Type *string `protobuf:"bytes,4,opt,name=type" json:"type,omitempty"`
+	// where clause of a partial index
+	Where *string `protobuf:"bytes,5,opt,name=where" json:"where,omitempty"`
+	// include lists the fields stored in the index without being part of its
+	// key, only in the DDL as GORM cannot create them
+	Include []string `protobuf:"bytes,6,rep,name=include" json:"include,omitempty"`
+	// class of the index on MySQL, FULLTEXT or SPATIAL
+	Class *string `protobuf:"bytes,7,opt,name=class" json:"class,omitempty"`
+}
+
+func (x *IndexDefinition) Reset() {
+	*x = IndexDefinition{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_options_gorm_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IndexDefinition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IndexDefinition) ProtoMessage() {}
+
+func (x *IndexDefinition) ProtoReflect() protoreflect.Message {
+	mi := &file_options_gorm_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IndexDefinition.ProtoReflect.Descriptor instead.
+func (*IndexDefinition) Descriptor() ([]byte, []int) {
+	return file_options_gorm_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *IndexDefinition) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *IndexDefinition) GetColumns() []*IndexColumn {
+	if x != nil {
+		return x.Columns
+	}
+	return nil
+}
+
+func (x *IndexDefinition) GetUnique() bool {
+	if x != nil && x.Unique != nil {
+		return *x.Unique
+	}
+	return false
+}
+
+func (x *IndexDefinition) GetType() string {
+	if x != nil && x.Type != nil {
+		return *x.Type
+	}
+	return ""
+}
+
+func (x *IndexDefinition) GetWhere() string {
+	if x != nil && x.Where != nil {
+		return *x.Where
+	}
+	return ""
+}
+
+func (x *IndexDefinition) GetInclude() []string {
+	if x != nil {
+		return x.Include
+	}
+	return nil
+}
+
+func (x *IndexDefinition) GetClass() string {
+	if x != nil && x.Class != nil {
+		return *x.Class
+	}
+	return ""
+}
+
+type IndexColumn struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// field of the column, the proto or Go name of a field of the ormable type
+	Field *string `protobuf:"bytes,1,req,name=field" json:"field,omitempty"`
+	// sort order of the column, ASC or DESC
+	Sort *string `protobuf:"bytes,2,opt,name=sort" json:"sort,omitempty"`
+}
+
+func (x *IndexColumn) Reset() {
+	*x = IndexColumn{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_options_gorm_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IndexColumn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IndexColumn) ProtoMessage() {}
+
+func (x *IndexColumn) ProtoReflect() protoreflect.Message {
+	mi := &file_options_gorm_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IndexColumn.ProtoReflect.Descriptor instead.
+func (*IndexColumn) Descriptor() ([]byte, []int) {
+	return file_options_gorm_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *IndexColumn) GetField() string {
+	if x != nil && x.Field != nil {
+		return *x.Field
+	}
+	return ""
+}
+
+func (x *IndexColumn) GetSort() string {
+	if x != nil && x.Sort != nil {
+		return *x.Sort
+	}
+	return ""
+}
+
 type ExtraField struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -203,7 +836,7 @@ type ExtraField struct {
 func (x *ExtraField) Reset() {
 	*x = ExtraField{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_options_gorm_proto_msgTypes[2]
+		mi := &file_options_gorm_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -216,7 +849,7 @@ func (x *ExtraField) String() string {
 func (*ExtraField) ProtoMessage() {}
 
 func (x *ExtraField) ProtoReflect() protoreflect.Message {
-	mi := &file_options_gorm_proto_msgTypes[2]
+	mi := &file_options_gorm_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -229,7 +862,7 @@ func (x *ExtraField) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExtraField.ProtoReflect.Descriptor instead.
 func (*ExtraField) Descriptor() ([]byte, []int) {
-	return file_options_gorm_proto_rawDescGZIP(), []int{2}
+	return file_options_gorm_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ExtraField) GetType() string {
@@ -274,12 +907,19 @@ type GormFieldOptions struct {
 	//	*GormFieldOptions_ManyToMany
 	Association isGormFieldOptions_Association `protobuf_oneof:"association"`
 	ReferenceOf *string                        `protobuf:"bytes,7,opt,name=reference_of,json=referenceOf" json:"reference_of,omitempty"`
+	// serialize stores a non-ormable message field in a single column
+	Serialize *SerializeFormat `protobuf:"varint,8,opt,name=serialize,enum=gorm.SerializeFormat" json:"serialize,omitempty"`
+	// enum_storage overrides the storage of an enum field set on its enum type
+	EnumStorage *EnumStorage `protobuf:"varint,9,opt,name=enum_storage,json=enumStorage,enum=gorm.EnumStorage" json:"enum_storage,omitempty"`
+	// renamed_from is the previous column of the field, the diff command
+	// renames that column instead of dropping it and adding a new one
+	RenamedFrom *string `protobuf:"bytes,10,opt,name=renamed_from,json=renamedFrom" json:"renamed_from,omitempty"`
 }
 
 func (x *GormFieldOptions) Reset() {
 	*x = GormFieldOptions{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_options_gorm_proto_msgTypes[3]
+		mi := &file_options_gorm_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -292,7 +932,7 @@ func (x *GormFieldOptions) String() string {
 func (*GormFieldOptions) ProtoMessage() {}
 
 func (x *GormFieldOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_options_gorm_proto_msgTypes[3]
+	mi := &file_options_gorm_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -305,7 +945,7 @@ func (x *GormFieldOptions) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GormFieldOptions.ProtoReflect.Descriptor instead.
 func (*GormFieldOptions) Descriptor() ([]byte, []int) {
-	return file_options_gorm_proto_rawDescGZIP(), []int{3}
+	return file_options_gorm_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *GormFieldOptions) GetTag() *GormTag {
@@ -364,6 +1004,27 @@ func (x *GormFieldOptions) GetReferenceOf() string {
 	return ""
 }
 
+func (x *GormFieldOptions) GetSerialize() SerializeFormat {
+	if x != nil && x.Serialize != nil {
+		return *x.Serialize
+	}
+	return SerializeFormat_JSON
+}
+
+func (x *GormFieldOptions) GetEnumStorage() EnumStorage {
+	if x != nil && x.EnumStorage != nil {
+		return *x.EnumStorage
+	}
+	return EnumStorage_INT
+}
+
+func (x *GormFieldOptions) GetRenamedFrom() string {
+	if x != nil && x.RenamedFrom != nil {
+		return *x.RenamedFrom
+	}
+	return ""
+}
+
 type isGormFieldOptions_Association interface {
 	isGormFieldOptions_Association()
 }
@@ -392,6 +1053,74 @@ func (*GormFieldOptions_HasMany) isGormFieldOptions_Association() {}
 
 func (*GormFieldOptions_ManyToMany) isGormFieldOptions_Association() {}
 
+type GormEnumOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// storage of the fields of this enum type, the enums plugin parameter
+	// decides between INT and STRING by default
+	Storage *EnumStorage `protobuf:"varint,1,opt,name=storage,enum=gorm.EnumStorage" json:"storage,omitempty"`
+	// trim_prefix stores the value names without the upper snake case enum
+	// name prefix and in lower case, e.g. STATUS_ACTIVE as active
+	TrimPrefix *bool `protobuf:"varint,2,opt,name=trim_prefix,json=trimPrefix" json:"trim_prefix,omitempty"`
+	// type_name of the native enum type, the snake case enum name by default
+	TypeName *string `protobuf:"bytes,3,opt,name=type_name,json=typeName" json:"type_name,omitempty"`
+}
+
+func (x *GormEnumOptions) Reset() {
+	*x = GormEnumOptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_options_gorm_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GormEnumOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GormEnumOptions) ProtoMessage() {}
+
+func (x *GormEnumOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_options_gorm_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GormEnumOptions.ProtoReflect.Descriptor instead.
+func (*GormEnumOptions) Descriptor() ([]byte, []int) {
+	return file_options_gorm_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GormEnumOptions) GetStorage() EnumStorage {
+	if x != nil && x.Storage != nil {
+		return *x.Storage
+	}
+	return EnumStorage_INT
+}
+
+func (x *GormEnumOptions) GetTrimPrefix() bool {
+	if x != nil && x.TrimPrefix != nil {
+		return *x.TrimPrefix
+	}
+	return false
+}
+
+func (x *GormEnumOptions) GetTypeName() string {
+	if x != nil && x.TypeName != nil {
+		return *x.TypeName
+	}
+	return ""
+}
+
 type GormTag struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -420,12 +1149,14 @@ type GormTag struct {
 	CanRead         *bool                 `protobuf:"varint,21,opt,name=canRead" json:"canRead,omitempty"`
 	WritePermission *FieldWritePermission `protobuf:"varint,22,opt,name=writePermission,enum=gorm.FieldWritePermission" json:"writePermission,omitempty"`
 	Constraint      *string               `protobuf:"bytes,23,opt,name=constraint" json:"constraint,omitempty"`
+	AutoCreateTime  *bool                 `protobuf:"varint,24,opt,name=auto_create_time,json=autoCreateTime" json:"auto_create_time,omitempty"`
+	AutoUpdateTime  *bool                 `protobuf:"varint,25,opt,name=auto_update_time,json=autoUpdateTime" json:"auto_update_time,omitempty"`
 }
 
 func (x *GormTag) Reset() {
 	*x = GormTag{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_options_gorm_proto_msgTypes[4]
+		mi := &file_options_gorm_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -438,7 +1169,7 @@ func (x *GormTag) String() string {
 func (*GormTag) ProtoMessage() {}
 
 func (x *GormTag) ProtoReflect() protoreflect.Message {
-	mi := &file_options_gorm_proto_msgTypes[4]
+	mi := &file_options_gorm_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -451,7 +1182,7 @@ func (x *GormTag) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GormTag.ProtoReflect.Descriptor instead.
 func (*GormTag) Descriptor() ([]byte, []int) {
-	return file_options_gorm_proto_rawDescGZIP(), []int{4}
+	return file_options_gorm_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *GormTag) GetColumn() string {
@@ -615,6 +1346,20 @@ func (x *GormTag) GetConstraint() string {
 	return ""
 }
 
+func (x *GormTag) GetAutoCreateTime() bool {
+	if x != nil && x.AutoCreateTime != nil {
+		return *x.AutoCreateTime
+	}
+	return false
+}
+
+func (x *GormTag) GetAutoUpdateTime() bool {
+	if x != nil && x.AutoUpdateTime != nil {
+		return *x.AutoUpdateTime
+	}
+	return false
+}
+
 type HasOneOptions struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -628,7 +1373,7 @@ type HasOneOptions struct {
 func (x *HasOneOptions) Reset() {
 	*x = HasOneOptions{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_options_gorm_proto_msgTypes[5]
+		mi := &file_options_gorm_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -641,7 +1386,7 @@ func (x *HasOneOptions) String() string {
 func (*HasOneOptions) ProtoMessage() {}
 
 func (x *HasOneOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_options_gorm_proto_msgTypes[5]
+	mi := &file_options_gorm_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -654,7 +1399,7 @@ func (x *HasOneOptions) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HasOneOptions.ProtoReflect.Descriptor instead.
 func (*HasOneOptions) Descriptor() ([]byte, []int) {
-	return file_options_gorm_proto_rawDescGZIP(), []int{5}
+	return file_options_gorm_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *HasOneOptions) GetForeignKey() string {
@@ -691,7 +1436,7 @@ type BelongsToOptions struct {
 func (x *BelongsToOptions) Reset() {
 	*x = BelongsToOptions{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_options_gorm_proto_msgTypes[6]
+		mi := &file_options_gorm_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -704,7 +1449,7 @@ func (x *BelongsToOptions) String() string {
 func (*BelongsToOptions) ProtoMessage() {}
 
 func (x *BelongsToOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_options_gorm_proto_msgTypes[6]
+	mi := &file_options_gorm_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -717,7 +1462,7 @@ func (x *BelongsToOptions) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BelongsToOptions.ProtoReflect.Descriptor instead.
 func (*BelongsToOptions) Descriptor() ([]byte, []int) {
-	return file_options_gorm_proto_rawDescGZIP(), []int{6}
+	return file_options_gorm_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *BelongsToOptions) GetForeignKey() string {
@@ -754,7 +1499,7 @@ type HasManyOptions struct {
 func (x *HasManyOptions) Reset() {
 	*x = HasManyOptions{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_options_gorm_proto_msgTypes[7]
+		mi := &file_options_gorm_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -767,7 +1512,7 @@ func (x *HasManyOptions) String() string {
 func (*HasManyOptions) ProtoMessage() {}
 
 func (x *HasManyOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_options_gorm_proto_msgTypes[7]
+	mi := &file_options_gorm_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -780,7 +1525,7 @@ func (x *HasManyOptions) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HasManyOptions.ProtoReflect.Descriptor instead.
 func (*HasManyOptions) Descriptor() ([]byte, []int) {
-	return file_options_gorm_proto_rawDescGZIP(), []int{7}
+	return file_options_gorm_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *HasManyOptions) GetForeignKey() string {
@@ -819,7 +1564,7 @@ type ManyToManyOptions struct {
 func (x *ManyToManyOptions) Reset() {
 	*x = ManyToManyOptions{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_options_gorm_proto_msgTypes[8]
+		mi := &file_options_gorm_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -832,7 +1577,7 @@ func (x *ManyToManyOptions) String() string {
 func (*ManyToManyOptions) ProtoMessage() {}
 
 func (x *ManyToManyOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_options_gorm_proto_msgTypes[8]
+	mi := &file_options_gorm_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -845,7 +1590,7 @@ func (x *ManyToManyOptions) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ManyToManyOptions.ProtoReflect.Descriptor instead.
 func (*ManyToManyOptions) Descriptor() ([]byte, []int) {
-	return file_options_gorm_proto_rawDescGZIP(), []int{8}
+	return file_options_gorm_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *ManyToManyOptions) GetJointable() string {
@@ -883,6 +1628,111 @@ func (x *ManyToManyOptions) GetJoinReferences() string {
 	return ""
 }
 
+type AutoServerOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Autogen *bool `protobuf:"varint,1,opt,name=autogen" json:"autogen,omitempty"`
+	// txn_middleware makes the generated server run each request inside the
+	// transaction put into the context by gorm.UnaryServerInterceptor
+	TxnMiddleware *bool `protobuf:"varint,2,opt,name=txn_middleware,json=txnMiddleware" json:"txn_middleware,omitempty"`
+}
+
+func (x *AutoServerOptions) Reset() {
+	*x = AutoServerOptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_options_gorm_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AutoServerOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AutoServerOptions) ProtoMessage() {}
+
+func (x *AutoServerOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_options_gorm_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AutoServerOptions.ProtoReflect.Descriptor instead.
+func (*AutoServerOptions) Descriptor() ([]byte, []int) {
+	return file_options_gorm_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *AutoServerOptions) GetAutogen() bool {
+	if x != nil && x.Autogen != nil {
+		return *x.Autogen
+	}
+	return false
+}
+
+func (x *AutoServerOptions) GetTxnMiddleware() bool {
+	if x != nil && x.TxnMiddleware != nil {
+		return *x.TxnMiddleware
+	}
+	return false
+}
+
+type MethodOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// object_type is the ormable type a Delete method works with
+	ObjectType *string `protobuf:"bytes,1,opt,name=object_type,json=objectType" json:"object_type,omitempty"`
+}
+
+func (x *MethodOptions) Reset() {
+	*x = MethodOptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_options_gorm_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MethodOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MethodOptions) ProtoMessage() {}
+
+func (x *MethodOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_options_gorm_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MethodOptions.ProtoReflect.Descriptor instead.
+func (*MethodOptions) Descriptor() ([]byte, []int) {
+	return file_options_gorm_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *MethodOptions) GetObjectType() string {
+	if x != nil && x.ObjectType != nil {
+		return *x.ObjectType
+	}
+	return ""
+}
+
 var file_options_gorm_proto_extTypes = []protoimpl.ExtensionInfo{
 	{
 		ExtendedType:  (*descriptor.FileOptions)(nil),
@@ -908,6 +1758,30 @@ var file_options_gorm_proto_extTypes = []protoimpl.ExtensionInfo{
 		Tag:           "bytes,52119,opt,name=field",
 		Filename:      "options/gorm.proto",
 	},
+	{
+		ExtendedType:  (*descriptor.EnumOptions)(nil),
+		ExtensionType: (*GormEnumOptions)(nil),
+		Field:         52119,
+		Name:          "gorm.enum",
+		Tag:           "bytes,52119,opt,name=enum",
+		Filename:      "options/gorm.proto",
+	},
+	{
+		ExtendedType:  (*descriptor.ServiceOptions)(nil),
+		ExtensionType: (*AutoServerOptions)(nil),
+		Field:         52119,
+		Name:          "gorm.server",
+		Tag:           "bytes,52119,opt,name=server",
+		Filename:      "options/gorm.proto",
+	},
+	{
+		ExtendedType:  (*descriptor.MethodOptions)(nil),
+		ExtensionType: (*MethodOptions)(nil),
+		Field:         52119,
+		Name:          "gorm.method",
+		Tag:           "bytes,52119,opt,name=method",
+		Filename:      "options/gorm.proto",
+	},
 }
 
 // Extension fields to descriptor.FileOptions.
@@ -930,105 +1804,214 @@ var (
 	E_Field = &file_options_gorm_proto_extTypes[2]
 )
 
+// Extension fields to descriptor.EnumOptions.
+var (
+	// optional gorm.GormEnumOptions enum = 52119;
+	E_Enum = &file_options_gorm_proto_extTypes[3]
+)
+
+// Extension fields to descriptor.ServiceOptions.
+var (
+	// server will cause a default grpc server to be generated for this service
+	//
+	// optional gorm.AutoServerOptions server = 52119;
+	E_Server = &file_options_gorm_proto_extTypes[4]
+)
+
+// Extension fields to descriptor.MethodOptions.
+var (
+	// optional gorm.MethodOptions method = 52119;
+	E_Method = &file_options_gorm_proto_extTypes[5]
+)
+
 var File_options_gorm_proto protoreflect.FileDescriptor
 
 var file_options_gorm_proto_rawDesc = []byte{
 	0x0a, 0x12, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x67, 0x6f, 0x72, 0x6d, 0x2e, 0x70,
 	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x04, 0x67, 0x6f, 0x72, 0x6d, 0x1a, 0x20, 0x67, 0x6f, 0x6f, 0x67,
 	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x65, 0x73, 0x63,
-	0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x11, 0x0a, 0x0f,
-	0x47, 0x6f, 0x72, 0x6d, 0x46, 0x69, 0x6c, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22,
-	0x70, 0x0a, 0x12, 0x47, 0x6f, 0x72, 0x6d, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x70,
-	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x72, 0x6d, 0x61, 0x62, 0x6c, 0x65,
-	0x18, 0x01, 0x20, 0x02, 0x28, 0x08, 0x52, 0x07, 0x6f, 0x72, 0x6d, 0x61, 0x62, 0x6c, 0x65, 0x12,
-	0x2a, 0x0a, 0x07, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x10, 0x2e, 0x67, 0x6f, 0x72, 0x6d, 0x2e, 0x45, 0x78, 0x74, 0x72, 0x61, 0x46, 0x69, 0x65,
-	0x6c, 0x64, 0x52, 0x07, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74,
-	0x61, 0x62, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x61, 0x62, 0x6c,
-	0x65, 0x22, 0x6f, 0x0a, 0x0a, 0x45, 0x78, 0x74, 0x72, 0x61, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12,
-	0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x02, 0x28, 0x09, 0x52, 0x04, 0x74,
-	0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x02, 0x28,
-	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x67, 0x6f, 0x72, 0x6d, 0x2e, 0x47, 0x6f, 0x72, 0x6d,
-	0x54, 0x61, 0x67, 0x52, 0x03, 0x74, 0x61, 0x67, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x63, 0x6b,
-	0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61, 0x63, 0x6b, 0x61,
-	0x67, 0x65, 0x22, 0xd2, 0x02, 0x0a, 0x10, 0x47, 0x6f, 0x72, 0x6d, 0x46, 0x69, 0x65, 0x6c, 0x64,
-	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1f, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x67, 0x6f, 0x72, 0x6d, 0x2e, 0x47, 0x6f, 0x72, 0x6d,
-	0x54, 0x61, 0x67, 0x52, 0x03, 0x74, 0x61, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x72, 0x6f, 0x70,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x64, 0x72, 0x6f, 0x70, 0x12, 0x2e, 0x0a, 0x07,
-	0x68, 0x61, 0x73, 0x5f, 0x6f, 0x6e, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e,
-	0x67, 0x6f, 0x72, 0x6d, 0x2e, 0x48, 0x61, 0x73, 0x4f, 0x6e, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x48, 0x00, 0x52, 0x06, 0x68, 0x61, 0x73, 0x4f, 0x6e, 0x65, 0x12, 0x37, 0x0a, 0x0a,
-	0x62, 0x65, 0x6c, 0x6f, 0x6e, 0x67, 0x73, 0x5f, 0x74, 0x6f, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x16, 0x2e, 0x67, 0x6f, 0x72, 0x6d, 0x2e, 0x42, 0x65, 0x6c, 0x6f, 0x6e, 0x67, 0x73, 0x54,
-	0x6f, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x48, 0x00, 0x52, 0x09, 0x62, 0x65, 0x6c, 0x6f,
-	0x6e, 0x67, 0x73, 0x54, 0x6f, 0x12, 0x31, 0x0a, 0x08, 0x68, 0x61, 0x73, 0x5f, 0x6d, 0x61, 0x6e,
-	0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x72, 0x6d, 0x2e, 0x48,
-	0x61, 0x73, 0x4d, 0x61, 0x6e, 0x79, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x48, 0x00, 0x52,
-	0x07, 0x68, 0x61, 0x73, 0x4d, 0x61, 0x6e, 0x79, 0x12, 0x3b, 0x0a, 0x0c, 0x6d, 0x61, 0x6e, 0x79,
-	0x5f, 0x74, 0x6f, 0x5f, 0x6d, 0x61, 0x6e, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17,
-	0x2e, 0x67, 0x6f, 0x72, 0x6d, 0x2e, 0x4d, 0x61, 0x6e, 0x79, 0x54, 0x6f, 0x4d, 0x61, 0x6e, 0x79,
-	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x48, 0x00, 0x52, 0x0a, 0x6d, 0x61, 0x6e, 0x79, 0x54,
-	0x6f, 0x4d, 0x61, 0x6e, 0x79, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e,
-	0x63, 0x65, 0x5f, 0x6f, 0x66, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65, 0x66,
-	0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x4f, 0x66, 0x42, 0x0d, 0x0a, 0x0b, 0x61, 0x73, 0x73, 0x6f,
-	0x63, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xde, 0x05, 0x0a, 0x07, 0x47, 0x6f, 0x72, 0x6d,
-	0x54, 0x61, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x74,
-	0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12,
-	0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x73,
-	0x69, 0x7a, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x72, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x70, 0x72, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f,
-	0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x5f, 0x6b, 0x65, 0x79,
-	0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x4b,
-	0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x6e, 0x69, 0x71, 0x75, 0x65, 0x18, 0x06, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x06, 0x75, 0x6e, 0x69, 0x71, 0x75, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65,
-	0x66, 0x61, 0x75, 0x6c, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x64, 0x65, 0x66,
-	0x61, 0x75, 0x6c, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x6e, 0x6f, 0x74, 0x5f, 0x6e, 0x75, 0x6c, 0x6c,
-	0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x6e, 0x6f, 0x74, 0x4e, 0x75, 0x6c, 0x6c, 0x12,
-	0x25, 0x0a, 0x0e, 0x61, 0x75, 0x74, 0x6f, 0x5f, 0x69, 0x6e, 0x63, 0x72, 0x65, 0x6d, 0x65, 0x6e,
-	0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x61, 0x75, 0x74, 0x6f, 0x49, 0x6e, 0x63,
-	0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18,
-	0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x21, 0x0a, 0x0c,
-	0x75, 0x6e, 0x69, 0x71, 0x75, 0x65, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x0b, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0b, 0x75, 0x6e, 0x69, 0x71, 0x75, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12,
-	0x1a, 0x0a, 0x08, 0x65, 0x6d, 0x62, 0x65, 0x64, 0x64, 0x65, 0x64, 0x18, 0x0c, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x08, 0x65, 0x6d, 0x62, 0x65, 0x64, 0x64, 0x65, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x65,
-	0x6d, 0x62, 0x65, 0x64, 0x64, 0x65, 0x64, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x0d,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x65, 0x6d, 0x62, 0x65, 0x64, 0x64, 0x65, 0x64, 0x50, 0x72,
-	0x65, 0x66, 0x69, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x18, 0x0e,
-	0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x12, 0x1f, 0x0a, 0x0b,
-	0x66, 0x6f, 0x72, 0x65, 0x69, 0x67, 0x6e, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x0f, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0a, 0x66, 0x6f, 0x72, 0x65, 0x69, 0x67, 0x6e, 0x4b, 0x65, 0x79, 0x12, 0x1e, 0x0a,
-	0x0a, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x10, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0a, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x20, 0x0a,
-	0x0c, 0x6d, 0x61, 0x6e, 0x79, 0x5f, 0x74, 0x6f, 0x5f, 0x6d, 0x61, 0x6e, 0x79, 0x18, 0x11, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x61, 0x6e, 0x79, 0x54, 0x6f, 0x4d, 0x61, 0x6e, 0x79, 0x12,
-	0x28, 0x0a, 0x10, 0x6a, 0x6f, 0x69, 0x6e, 0x5f, 0x66, 0x6f, 0x72, 0x65, 0x69, 0x67, 0x6e, 0x5f,
-	0x6b, 0x65, 0x79, 0x18, 0x12, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x6a, 0x6f, 0x69, 0x6e, 0x46,
-	0x6f, 0x72, 0x65, 0x69, 0x67, 0x6e, 0x4b, 0x65, 0x79, 0x12, 0x27, 0x0a, 0x0f, 0x6a, 0x6f, 0x69,
-	0x6e, 0x5f, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x13, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0e, 0x6a, 0x6f, 0x69, 0x6e, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63,
-	0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x18, 0x14, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x05, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x61, 0x6e, 0x52,
-	0x65, 0x61, 0x64, 0x18, 0x15, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x63, 0x61, 0x6e, 0x52, 0x65,
-	0x61, 0x64, 0x12, 0x44, 0x0a, 0x0f, 0x77, 0x72, 0x69, 0x74, 0x65, 0x50, 0x65, 0x72, 0x6d, 0x69,
-	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x16, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
-	0x72, 0x6d, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x57, 0x72, 0x69, 0x74, 0x65, 0x50, 0x65, 0x72,
-	0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0f, 0x77, 0x72, 0x69, 0x74, 0x65, 0x50, 0x65,
-	0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x73,
-	0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x18, 0x17, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f,
-	0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x22, 0x87, 0x01, 0x0a, 0x0d, 0x48, 0x61, 0x73,
-	0x4f, 0x6e, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x6f,
-	0x72, 0x65, 0x69, 0x67, 0x6e, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0a, 0x66, 0x6f, 0x72, 0x65, 0x69, 0x67, 0x6e, 0x4b, 0x65, 0x79, 0x12, 0x35, 0x0a, 0x0f, 0x66,
-	0x6f, 0x72, 0x65, 0x69, 0x67, 0x6e, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x74, 0x61, 0x67, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x67, 0x6f, 0x72, 0x6d, 0x2e, 0x47, 0x6f, 0x72, 0x6d,
-	0x54, 0x61, 0x67, 0x52, 0x0d, 0x66, 0x6f, 0x72, 0x65, 0x69, 0x67, 0x6e, 0x4b, 0x65, 0x79, 0x54,
-	0x61, 0x67, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63,
-	0x65, 0x73, 0x22, 0x8a, 0x01, 0x0a, 0x10, 0x42, 0x65, 0x6c, 0x6f, 0x6e, 0x67, 0x73, 0x54, 0x6f,
+	0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xf0, 0x01, 0x0a,
+	0x0f, 0x47, 0x6f, 0x72, 0x6d, 0x46, 0x69, 0x6c, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x12, 0x2c, 0x0a, 0x06, 0x6e, 0x61, 0x6d, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x67, 0x6f, 0x72, 0x6d, 0x2e, 0x4e, 0x61, 0x6d, 0x69, 0x6e, 0x67, 0x53, 0x74,
+	0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x52, 0x06, 0x6e, 0x61, 0x6d, 0x69, 0x6e, 0x67, 0x12, 0x2c,
+	0x0a, 0x12, 0x6f, 0x72, 0x6d, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x62, 0x79, 0x5f, 0x64, 0x65, 0x66,
+	0x61, 0x75, 0x6c, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x6f, 0x72, 0x6d, 0x61,
+	0x62, 0x6c, 0x65, 0x42, 0x79, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x65, 0x6e,
+	0x67, 0x69, 0x6e, 0x65, 0x12, 0x34, 0x0a, 0x0c, 0x65, 0x6e, 0x75, 0x6d, 0x5f, 0x73, 0x74, 0x6f,
+	0x72, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x67, 0x6f, 0x72,
+	0x6d, 0x2e, 0x45, 0x6e, 0x75, 0x6d, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x52, 0x0b, 0x65,
+	0x6e, 0x75, 0x6d, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x12, 0x33, 0x0a, 0x0c, 0x64, 0x65,
+	0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x74, 0x61, 0x67, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x10, 0x2e, 0x67, 0x6f, 0x72, 0x6d, 0x2e, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x54,
+	0x61, 0x67, 0x52, 0x0b, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x54, 0x61, 0x67, 0x73, 0x22,
+	0x43, 0x0a, 0x0a, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x54, 0x61, 0x67, 0x12, 0x14, 0x0a,
+	0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x01, 0x20, 0x02, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69,
+	0x65, 0x6c, 0x64, 0x12, 0x1f, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0d, 0x2e, 0x67, 0x6f, 0x72, 0x6d, 0x2e, 0x47, 0x6f, 0x72, 0x6d, 0x54, 0x61, 0x67, 0x52,
+	0x03, 0x74, 0x61, 0x67, 0x22, 0xbc, 0x01, 0x0a, 0x0e, 0x4e, 0x61, 0x6d, 0x69, 0x6e, 0x67, 0x53,
+	0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x12, 0x22, 0x0a, 0x04, 0x63, 0x61, 0x73, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x67, 0x6f, 0x72, 0x6d, 0x2e, 0x4e, 0x61, 0x6d,
+	0x65, 0x43, 0x61, 0x73, 0x65, 0x52, 0x04, 0x63, 0x61, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x73,
+	0x69, 0x6e, 0x67, 0x75, 0x6c, 0x61, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x73,
+	0x69, 0x6e, 0x67, 0x75, 0x6c, 0x61, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x61, 0x62, 0x6c, 0x65,
+	0x5f, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x74,
+	0x61, 0x62, 0x6c, 0x65, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63,
+	0x68, 0x65, 0x6d, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65,
+	0x6d, 0x61, 0x12, 0x2f, 0x0a, 0x0b, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x5f, 0x63, 0x61, 0x73,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x67, 0x6f, 0x72, 0x6d, 0x2e, 0x4e,
+	0x61, 0x6d, 0x65, 0x43, 0x61, 0x73, 0x65, 0x52, 0x0a, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x43,
+	0x61, 0x73, 0x65, 0x22, 0xb0, 0x02, 0x0a, 0x12, 0x47, 0x6f, 0x72, 0x6d, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x72,
+	0x6d, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x6f, 0x72, 0x6d,
+	0x61, 0x62, 0x6c, 0x65, 0x12, 0x2a, 0x0a, 0x07, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x67, 0x6f, 0x72, 0x6d, 0x2e, 0x45, 0x78, 0x74,
+	0x72, 0x61, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x52, 0x07, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x2f, 0x0a, 0x07, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x65,
+	0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67, 0x6f, 0x72, 0x6d, 0x2e, 0x49,
+	0x6e, 0x64, 0x65, 0x78, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07,
+	0x69, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x6f, 0x66, 0x74, 0x5f,
+	0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x73, 0x6f,
+	0x66, 0x74, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x2c, 0x0a, 0x12, 0x73, 0x6f, 0x66, 0x74,
+	0x5f, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x5f, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x73, 0x6f, 0x66, 0x74, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12, 0x3e, 0x0a, 0x10, 0x73, 0x6f, 0x66, 0x74, 0x5f, 0x64,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x14, 0x2e, 0x67, 0x6f, 0x72, 0x6d, 0x2e, 0x53, 0x6f, 0x66, 0x74, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x0e, 0x73, 0x6f, 0x66, 0x74, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x22, 0xc4, 0x01, 0x0a, 0x0f, 0x49, 0x6e, 0x64, 0x65, 0x78,
+	0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2b,
+	0x0a, 0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x11, 0x2e, 0x67, 0x6f, 0x72, 0x6d, 0x2e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x43, 0x6f, 0x6c, 0x75,
+	0x6d, 0x6e, 0x52, 0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x75,
+	0x6e, 0x69, 0x71, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x75, 0x6e, 0x69,
+	0x71, 0x75, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x68, 0x65, 0x72, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x77, 0x68, 0x65, 0x72, 0x65, 0x12, 0x18, 0x0a,
+	0x07, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07,
+	0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6c, 0x61, 0x73, 0x73,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x22, 0x37, 0x0a,
+	0x0b, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12, 0x14, 0x0a, 0x05,
+	0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x01, 0x20, 0x02, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x65,
+	0x6c, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x73, 0x6f, 0x72, 0x74, 0x22, 0x6f, 0x0a, 0x0a, 0x45, 0x78, 0x74, 0x72, 0x61, 0x46,
+	0x69, 0x65, 0x6c, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x02,
+	0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x02, 0x20, 0x02, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x03,
+	0x74, 0x61, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x67, 0x6f, 0x72, 0x6d,
+	0x2e, 0x47, 0x6f, 0x72, 0x6d, 0x54, 0x61, 0x67, 0x52, 0x03, 0x74, 0x61, 0x67, 0x12, 0x18, 0x0a,
+	0x07, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x22, 0xe0, 0x03, 0x0a, 0x10, 0x47, 0x6f, 0x72, 0x6d,
+	0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1f, 0x0a, 0x03,
+	0x74, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x67, 0x6f, 0x72, 0x6d,
+	0x2e, 0x47, 0x6f, 0x72, 0x6d, 0x54, 0x61, 0x67, 0x52, 0x03, 0x74, 0x61, 0x67, 0x12, 0x12, 0x0a,
+	0x04, 0x64, 0x72, 0x6f, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x64, 0x72, 0x6f,
+	0x70, 0x12, 0x2e, 0x0a, 0x07, 0x68, 0x61, 0x73, 0x5f, 0x6f, 0x6e, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x67, 0x6f, 0x72, 0x6d, 0x2e, 0x48, 0x61, 0x73, 0x4f, 0x6e, 0x65,
+	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x48, 0x00, 0x52, 0x06, 0x68, 0x61, 0x73, 0x4f, 0x6e,
+	0x65, 0x12, 0x37, 0x0a, 0x0a, 0x62, 0x65, 0x6c, 0x6f, 0x6e, 0x67, 0x73, 0x5f, 0x74, 0x6f, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x72, 0x6d, 0x2e, 0x42, 0x65, 0x6c,
+	0x6f, 0x6e, 0x67, 0x73, 0x54, 0x6f, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x48, 0x00, 0x52,
+	0x09, 0x62, 0x65, 0x6c, 0x6f, 0x6e, 0x67, 0x73, 0x54, 0x6f, 0x12, 0x31, 0x0a, 0x08, 0x68, 0x61,
+	0x73, 0x5f, 0x6d, 0x61, 0x6e, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67,
+	0x6f, 0x72, 0x6d, 0x2e, 0x48, 0x61, 0x73, 0x4d, 0x61, 0x6e, 0x79, 0x4f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x48, 0x00, 0x52, 0x07, 0x68, 0x61, 0x73, 0x4d, 0x61, 0x6e, 0x79, 0x12, 0x3b, 0x0a,
+	0x0c, 0x6d, 0x61, 0x6e, 0x79, 0x5f, 0x74, 0x6f, 0x5f, 0x6d, 0x61, 0x6e, 0x79, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x72, 0x6d, 0x2e, 0x4d, 0x61, 0x6e, 0x79, 0x54,
+	0x6f, 0x4d, 0x61, 0x6e, 0x79, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x48, 0x00, 0x52, 0x0a,
+	0x6d, 0x61, 0x6e, 0x79, 0x54, 0x6f, 0x4d, 0x61, 0x6e, 0x79, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65,
+	0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x6f, 0x66, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x4f, 0x66, 0x12, 0x33, 0x0a,
+	0x09, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x15, 0x2e, 0x67, 0x6f, 0x72, 0x6d, 0x2e, 0x53, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a,
+	0x65, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52, 0x09, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69,
+	0x7a, 0x65, 0x12, 0x34, 0x0a, 0x0c, 0x65, 0x6e, 0x75, 0x6d, 0x5f, 0x73, 0x74, 0x6f, 0x72, 0x61,
+	0x67, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x67, 0x6f, 0x72, 0x6d, 0x2e,
+	0x45, 0x6e, 0x75, 0x6d, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x52, 0x0b, 0x65, 0x6e, 0x75,
+	0x6d, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x6e, 0x61,
+	0x6d, 0x65, 0x64, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x72, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x64, 0x46, 0x72, 0x6f, 0x6d, 0x42, 0x0d, 0x0a, 0x0b, 0x61,
+	0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x7c, 0x0a, 0x0f, 0x47, 0x6f,
+	0x72, 0x6d, 0x45, 0x6e, 0x75, 0x6d, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2b, 0x0a,
+	0x07, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11,
+	0x2e, 0x67, 0x6f, 0x72, 0x6d, 0x2e, 0x45, 0x6e, 0x75, 0x6d, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67,
+	0x65, 0x52, 0x07, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x72,
+	0x69, 0x6d, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0a, 0x74, 0x72, 0x69, 0x6d, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x1b, 0x0a, 0x09, 0x74,
+	0x79, 0x70, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x74, 0x79, 0x70, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0xb2, 0x06, 0x0a, 0x07, 0x47, 0x6f, 0x72,
+	0x6d, 0x54, 0x61, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12, 0x12, 0x0a, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04,
+	0x73, 0x69, 0x7a, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x72, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x70, 0x72, 0x65, 0x63, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x5f, 0x6b, 0x65,
+	0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79,
+	0x4b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x6e, 0x69, 0x71, 0x75, 0x65, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x06, 0x75, 0x6e, 0x69, 0x71, 0x75, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x64,
+	0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x64, 0x65,
+	0x66, 0x61, 0x75, 0x6c, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x6e, 0x6f, 0x74, 0x5f, 0x6e, 0x75, 0x6c,
+	0x6c, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x6e, 0x6f, 0x74, 0x4e, 0x75, 0x6c, 0x6c,
+	0x12, 0x25, 0x0a, 0x0e, 0x61, 0x75, 0x74, 0x6f, 0x5f, 0x69, 0x6e, 0x63, 0x72, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x61, 0x75, 0x74, 0x6f, 0x49, 0x6e,
+	0x63, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x21, 0x0a,
+	0x0c, 0x75, 0x6e, 0x69, 0x71, 0x75, 0x65, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x0b, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x75, 0x6e, 0x69, 0x71, 0x75, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78,
+	0x12, 0x1a, 0x0a, 0x08, 0x65, 0x6d, 0x62, 0x65, 0x64, 0x64, 0x65, 0x64, 0x18, 0x0c, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x08, 0x65, 0x6d, 0x62, 0x65, 0x64, 0x64, 0x65, 0x64, 0x12, 0x27, 0x0a, 0x0f,
+	0x65, 0x6d, 0x62, 0x65, 0x64, 0x64, 0x65, 0x64, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18,
+	0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x65, 0x6d, 0x62, 0x65, 0x64, 0x64, 0x65, 0x64, 0x50,
+	0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x18,
+	0x0e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x12, 0x1f, 0x0a,
+	0x0b, 0x66, 0x6f, 0x72, 0x65, 0x69, 0x67, 0x6e, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x0f, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x66, 0x6f, 0x72, 0x65, 0x69, 0x67, 0x6e, 0x4b, 0x65, 0x79, 0x12, 0x1e,
+	0x0a, 0x0a, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x10, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x20,
+	0x0a, 0x0c, 0x6d, 0x61, 0x6e, 0x79, 0x5f, 0x74, 0x6f, 0x5f, 0x6d, 0x61, 0x6e, 0x79, 0x18, 0x11,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x61, 0x6e, 0x79, 0x54, 0x6f, 0x4d, 0x61, 0x6e, 0x79,
+	0x12, 0x28, 0x0a, 0x10, 0x6a, 0x6f, 0x69, 0x6e, 0x5f, 0x66, 0x6f, 0x72, 0x65, 0x69, 0x67, 0x6e,
+	0x5f, 0x6b, 0x65, 0x79, 0x18, 0x12, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x6a, 0x6f, 0x69, 0x6e,
+	0x46, 0x6f, 0x72, 0x65, 0x69, 0x67, 0x6e, 0x4b, 0x65, 0x79, 0x12, 0x27, 0x0a, 0x0f, 0x6a, 0x6f,
+	0x69, 0x6e, 0x5f, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x13, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0e, 0x6a, 0x6f, 0x69, 0x6e, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e,
+	0x63, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x18, 0x14, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x61, 0x6e,
+	0x52, 0x65, 0x61, 0x64, 0x18, 0x15, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x63, 0x61, 0x6e, 0x52,
+	0x65, 0x61, 0x64, 0x12, 0x44, 0x0a, 0x0f, 0x77, 0x72, 0x69, 0x74, 0x65, 0x50, 0x65, 0x72, 0x6d,
+	0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x16, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x72, 0x6d, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x57, 0x72, 0x69, 0x74, 0x65, 0x50, 0x65,
+	0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0f, 0x77, 0x72, 0x69, 0x74, 0x65, 0x50,
+	0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6e,
+	0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x18, 0x17, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63,
+	0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x12, 0x28, 0x0a, 0x10, 0x61, 0x75, 0x74,
+	0x6f, 0x5f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x18, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0e, 0x61, 0x75, 0x74, 0x6f, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54,
+	0x69, 0x6d, 0x65, 0x12, 0x28, 0x0a, 0x10, 0x61, 0x75, 0x74, 0x6f, 0x5f, 0x75, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x19, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x61,
+	0x75, 0x74, 0x6f, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x87, 0x01,
+	0x0a, 0x0d, 0x48, 0x61, 0x73, 0x4f, 0x6e, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x1f, 0x0a, 0x0b, 0x66, 0x6f, 0x72, 0x65, 0x69, 0x67, 0x6e, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x66, 0x6f, 0x72, 0x65, 0x69, 0x67, 0x6e, 0x4b, 0x65, 0x79,
+	0x12, 0x35, 0x0a, 0x0f, 0x66, 0x6f, 0x72, 0x65, 0x69, 0x67, 0x6e, 0x5f, 0x6b, 0x65, 0x79, 0x5f,
+	0x74, 0x61, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x67, 0x6f, 0x72, 0x6d,
+	0x2e, 0x47, 0x6f, 0x72, 0x6d, 0x54, 0x61, 0x67, 0x52, 0x0d, 0x66, 0x6f, 0x72, 0x65, 0x69, 0x67,
+	0x6e, 0x4b, 0x65, 0x79, 0x54, 0x61, 0x67, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x66, 0x65, 0x72,
+	0x65, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x66,
+	0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x22, 0x8a, 0x01, 0x0a, 0x10, 0x42, 0x65, 0x6c, 0x6f,
+	0x6e, 0x67, 0x73, 0x54, 0x6f, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1f, 0x0a, 0x0b,
+	0x66, 0x6f, 0x72, 0x65, 0x69, 0x67, 0x6e, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x66, 0x6f, 0x72, 0x65, 0x69, 0x67, 0x6e, 0x4b, 0x65, 0x79, 0x12, 0x35, 0x0a,
+	0x0f, 0x66, 0x6f, 0x72, 0x65, 0x69, 0x67, 0x6e, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x74, 0x61, 0x67,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x67, 0x6f, 0x72, 0x6d, 0x2e, 0x47, 0x6f,
+	0x72, 0x6d, 0x54, 0x61, 0x67, 0x52, 0x0d, 0x66, 0x6f, 0x72, 0x65, 0x69, 0x67, 0x6e, 0x4b, 0x65,
+	0x79, 0x54, 0x61, 0x67, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63,
+	0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65,
+	0x6e, 0x63, 0x65, 0x73, 0x22, 0x88, 0x01, 0x0a, 0x0e, 0x48, 0x61, 0x73, 0x4d, 0x61, 0x6e, 0x79,
 	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x6f, 0x72, 0x65, 0x69,
 	0x67, 0x6e, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x66, 0x6f,
 	0x72, 0x65, 0x69, 0x67, 0x6e, 0x4b, 0x65, 0x79, 0x12, 0x35, 0x0a, 0x0f, 0x66, 0x6f, 0x72, 0x65,
@@ -1037,56 +2020,84 @@ var file_options_gorm_proto_rawDesc = []byte{
 	0x52, 0x0d, 0x66, 0x6f, 0x72, 0x65, 0x69, 0x67, 0x6e, 0x4b, 0x65, 0x79, 0x54, 0x61, 0x67, 0x12,
 	0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x03, 0x20,
 	0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x22,
-	0x88, 0x01, 0x0a, 0x0e, 0x48, 0x61, 0x73, 0x4d, 0x61, 0x6e, 0x79, 0x4f, 0x70, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x6f, 0x72, 0x65, 0x69, 0x67, 0x6e, 0x5f, 0x6b, 0x65,
-	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x66, 0x6f, 0x72, 0x65, 0x69, 0x67, 0x6e,
-	0x4b, 0x65, 0x79, 0x12, 0x35, 0x0a, 0x0f, 0x66, 0x6f, 0x72, 0x65, 0x69, 0x67, 0x6e, 0x5f, 0x6b,
-	0x65, 0x79, 0x5f, 0x74, 0x61, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x67,
-	0x6f, 0x72, 0x6d, 0x2e, 0x47, 0x6f, 0x72, 0x6d, 0x54, 0x61, 0x67, 0x52, 0x0d, 0x66, 0x6f, 0x72,
-	0x65, 0x69, 0x67, 0x6e, 0x4b, 0x65, 0x79, 0x54, 0x61, 0x67, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65,
-	0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
-	0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x22, 0xc5, 0x01, 0x0a, 0x11, 0x4d,
-	0x61, 0x6e, 0x79, 0x54, 0x6f, 0x4d, 0x61, 0x6e, 0x79, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
-	0x12, 0x1c, 0x0a, 0x09, 0x6a, 0x6f, 0x69, 0x6e, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x09, 0x6a, 0x6f, 0x69, 0x6e, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x1f,
-	0x0a, 0x0b, 0x66, 0x6f, 0x72, 0x65, 0x69, 0x67, 0x6e, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0a, 0x66, 0x6f, 0x72, 0x65, 0x69, 0x67, 0x6e, 0x4b, 0x65, 0x79, 0x12,
-	0x28, 0x0a, 0x10, 0x6a, 0x6f, 0x69, 0x6e, 0x5f, 0x66, 0x6f, 0x72, 0x65, 0x69, 0x67, 0x6e, 0x5f,
-	0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x6a, 0x6f, 0x69, 0x6e, 0x46,
-	0x6f, 0x72, 0x65, 0x69, 0x67, 0x6e, 0x4b, 0x65, 0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x66,
-	0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72,
-	0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x6a, 0x6f, 0x69,
-	0x6e, 0x5f, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0e, 0x6a, 0x6f, 0x69, 0x6e, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63,
-	0x65, 0x73, 0x2a, 0xa0, 0x01, 0x0a, 0x14, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x57, 0x72, 0x69, 0x74,
-	0x65, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x22, 0x0a, 0x1e, 0x46,
+	0xc5, 0x01, 0x0a, 0x11, 0x4d, 0x61, 0x6e, 0x79, 0x54, 0x6f, 0x4d, 0x61, 0x6e, 0x79, 0x4f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x6a, 0x6f, 0x69, 0x6e, 0x74, 0x61, 0x62,
+	0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6a, 0x6f, 0x69, 0x6e, 0x74, 0x61,
+	0x62, 0x6c, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x6f, 0x72, 0x65, 0x69, 0x67, 0x6e, 0x5f, 0x6b,
+	0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x66, 0x6f, 0x72, 0x65, 0x69, 0x67,
+	0x6e, 0x4b, 0x65, 0x79, 0x12, 0x28, 0x0a, 0x10, 0x6a, 0x6f, 0x69, 0x6e, 0x5f, 0x66, 0x6f, 0x72,
+	0x65, 0x69, 0x67, 0x6e, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e,
+	0x6a, 0x6f, 0x69, 0x6e, 0x46, 0x6f, 0x72, 0x65, 0x69, 0x67, 0x6e, 0x4b, 0x65, 0x79, 0x12, 0x1e,
+	0x0a, 0x0a, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x27,
+	0x0a, 0x0f, 0x6a, 0x6f, 0x69, 0x6e, 0x5f, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65,
+	0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x6a, 0x6f, 0x69, 0x6e, 0x52, 0x65, 0x66,
+	0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x22, 0x54, 0x0a, 0x11, 0x41, 0x75, 0x74, 0x6f, 0x53,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x18, 0x0a, 0x07,
+	0x61, 0x75, 0x74, 0x6f, 0x67, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x61,
+	0x75, 0x74, 0x6f, 0x67, 0x65, 0x6e, 0x12, 0x25, 0x0a, 0x0e, 0x74, 0x78, 0x6e, 0x5f, 0x6d, 0x69,
+	0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d,
+	0x74, 0x78, 0x6e, 0x4d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x22, 0x30, 0x0a,
+	0x0d, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1f,
+	0x0a, 0x0b, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x54, 0x79, 0x70, 0x65, 0x2a,
+	0x2a, 0x0a, 0x08, 0x4e, 0x61, 0x6d, 0x65, 0x43, 0x61, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x0a, 0x53,
+	0x4e, 0x41, 0x4b, 0x45, 0x5f, 0x43, 0x41, 0x53, 0x45, 0x10, 0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x43,
+	0x41, 0x4d, 0x45, 0x4c, 0x5f, 0x43, 0x41, 0x53, 0x45, 0x10, 0x02, 0x2a, 0x3e, 0x0a, 0x0e, 0x53,
+	0x6f, 0x66, 0x74, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x0e, 0x0a,
+	0x0a, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x44, 0x5f, 0x41, 0x54, 0x10, 0x01, 0x12, 0x12, 0x0a,
+	0x0e, 0x55, 0x4e, 0x49, 0x58, 0x5f, 0x54, 0x49, 0x4d, 0x45, 0x53, 0x54, 0x41, 0x4d, 0x50, 0x10,
+	0x02, 0x12, 0x08, 0x0a, 0x04, 0x46, 0x4c, 0x41, 0x47, 0x10, 0x03, 0x2a, 0x2d, 0x0a, 0x0f, 0x53,
+	0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x08,
+	0x0a, 0x04, 0x4a, 0x53, 0x4f, 0x4e, 0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c, 0x50, 0x52, 0x4f, 0x54,
+	0x4f, 0x5f, 0x42, 0x49, 0x4e, 0x41, 0x52, 0x59, 0x10, 0x02, 0x2a, 0x2e, 0x0a, 0x0b, 0x45, 0x6e,
+	0x75, 0x6d, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x12, 0x07, 0x0a, 0x03, 0x49, 0x4e, 0x54,
+	0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x54, 0x52, 0x49, 0x4e, 0x47, 0x10, 0x02, 0x12, 0x0a,
+	0x0a, 0x06, 0x4e, 0x41, 0x54, 0x49, 0x56, 0x45, 0x10, 0x03, 0x2a, 0xa0, 0x01, 0x0a, 0x14, 0x46,
+	0x69, 0x65, 0x6c, 0x64, 0x57, 0x72, 0x69, 0x74, 0x65, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x12, 0x22, 0x0a, 0x1e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x57, 0x72, 0x69, 0x74,
+	0x65, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x4f, 0x6e, 0x6c, 0x79, 0x10, 0x01, 0x12, 0x22, 0x0a, 0x1e, 0x46, 0x69, 0x65, 0x6c, 0x64,
+	0x57, 0x72, 0x69, 0x74, 0x65, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x4f, 0x6e, 0x6c, 0x79, 0x10, 0x02, 0x12, 0x1a, 0x0a, 0x16, 0x46,
 	0x69, 0x65, 0x6c, 0x64, 0x57, 0x72, 0x69, 0x74, 0x65, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73,
-	0x69, 0x6f, 0x6e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4f, 0x6e, 0x6c, 0x79, 0x10, 0x01, 0x12,
-	0x22, 0x0a, 0x1e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x57, 0x72, 0x69, 0x74, 0x65, 0x50, 0x65, 0x72,
-	0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4f, 0x6e, 0x6c,
-	0x79, 0x10, 0x02, 0x12, 0x1a, 0x0a, 0x16, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x57, 0x72, 0x69, 0x74,
-	0x65, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4e, 0x6f, 0x10, 0x03, 0x12,
-	0x24, 0x0a, 0x20, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x57, 0x72, 0x69, 0x74, 0x65, 0x50, 0x65, 0x72,
-	0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x55, 0x70, 0x64,
-	0x61, 0x74, 0x65, 0x10, 0x04, 0x3a, 0x52, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x6f, 0x70,
-	0x74, 0x73, 0x12, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
-	0x18, 0x97, 0x97, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67, 0x6f, 0x72, 0x6d, 0x2e,
-	0x47, 0x6f, 0x72, 0x6d, 0x46, 0x69, 0x6c, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52,
-	0x08, 0x66, 0x69, 0x6c, 0x65, 0x4f, 0x70, 0x74, 0x73, 0x3a, 0x4f, 0x0a, 0x04, 0x6f, 0x70, 0x74,
-	0x73, 0x12, 0x1f, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x18, 0x97, 0x97, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x67, 0x6f, 0x72,
-	0x6d, 0x2e, 0x47, 0x6f, 0x72, 0x6d, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x70, 0x74,
-	0x69, 0x6f, 0x6e, 0x73, 0x52, 0x04, 0x6f, 0x70, 0x74, 0x73, 0x3a, 0x4d, 0x0a, 0x05, 0x66, 0x69,
-	0x65, 0x6c, 0x64, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x18, 0x97, 0x97, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x72,
-	0x6d, 0x2e, 0x47, 0x6f, 0x72, 0x6d, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74,
-	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x54, 0x68, 0x65, 0x53, 0x44, 0x54, 0x4d, 0x2f,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x2d, 0x67, 0x65, 0x6e, 0x2d, 0x67, 0x6f, 0x72, 0x6d, 0x2f,
-	0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x3b, 0x67, 0x6f, 0x72, 0x6d,
+	0x69, 0x6f, 0x6e, 0x4e, 0x6f, 0x10, 0x03, 0x12, 0x24, 0x0a, 0x20, 0x46, 0x69, 0x65, 0x6c, 0x64,
+	0x57, 0x72, 0x69, 0x74, 0x65, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x10, 0x04, 0x3a, 0x52, 0x0a,
+	0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x6f, 0x70, 0x74, 0x73, 0x12, 0x1c, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x6c,
+	0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x97, 0x97, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x15, 0x2e, 0x67, 0x6f, 0x72, 0x6d, 0x2e, 0x47, 0x6f, 0x72, 0x6d, 0x46, 0x69, 0x6c, 0x65,
+	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x4f, 0x70, 0x74,
+	0x73, 0x3a, 0x4f, 0x0a, 0x04, 0x6f, 0x70, 0x74, 0x73, 0x12, 0x1f, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x97, 0x97, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x18, 0x2e, 0x67, 0x6f, 0x72, 0x6d, 0x2e, 0x47, 0x6f, 0x72, 0x6d, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x04, 0x6f, 0x70,
+	0x74, 0x73, 0x3a, 0x4d, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x1d, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69,
+	0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x97, 0x97, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x72, 0x6d, 0x2e, 0x47, 0x6f, 0x72, 0x6d, 0x46, 0x69,
+	0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c,
+	0x64, 0x3a, 0x49, 0x0a, 0x04, 0x65, 0x6e, 0x75, 0x6d, 0x12, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6e, 0x75, 0x6d,
+	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x97, 0x97, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x15, 0x2e, 0x67, 0x6f, 0x72, 0x6d, 0x2e, 0x47, 0x6f, 0x72, 0x6d, 0x45, 0x6e, 0x75, 0x6d, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x04, 0x65, 0x6e, 0x75, 0x6d, 0x3a, 0x52, 0x0a, 0x06,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x1f, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x97, 0x97, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x17, 0x2e, 0x67, 0x6f, 0x72, 0x6d, 0x2e, 0x41, 0x75, 0x74, 0x6f, 0x53, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x3a, 0x4d, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x1e, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65, 0x74,
+	0x68, 0x6f, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x97, 0x97, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x67, 0x6f, 0x72, 0x6d, 0x2e, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64,
+	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x42,
+	0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x54, 0x68,
+	0x65, 0x53, 0x44, 0x54, 0x4d, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x2d, 0x67, 0x65, 0x6e,
+	0x2d, 0x67, 0x6f, 0x72, 0x6d, 0x2f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x3b, 0x67, 0x6f,
+	0x72, 0x6d,
 }
 
 var (
@@ -1101,46 +2112,78 @@ func file_options_gorm_proto_rawDescGZIP() []byte {
 	return file_options_gorm_proto_rawDescData
 }
 
-var file_options_gorm_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_options_gorm_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_options_gorm_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_options_gorm_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
 var file_options_gorm_proto_goTypes = []interface{}{
-	(FieldWritePermission)(0),         // 0: gorm.FieldWritePermission
-	(*GormFileOptions)(nil),           // 1: gorm.GormFileOptions
-	(*GormMessageOptions)(nil),        // 2: gorm.GormMessageOptions
-	(*ExtraField)(nil),                // 3: gorm.ExtraField
-	(*GormFieldOptions)(nil),          // 4: gorm.GormFieldOptions
-	(*GormTag)(nil),                   // 5: gorm.GormTag
-	(*HasOneOptions)(nil),             // 6: gorm.HasOneOptions
-	(*BelongsToOptions)(nil),          // 7: gorm.BelongsToOptions
-	(*HasManyOptions)(nil),            // 8: gorm.HasManyOptions
-	(*ManyToManyOptions)(nil),         // 9: gorm.ManyToManyOptions
-	(*descriptor.FileOptions)(nil),    // 10: google.protobuf.FileOptions
-	(*descriptor.MessageOptions)(nil), // 11: google.protobuf.MessageOptions
-	(*descriptor.FieldOptions)(nil),   // 12: google.protobuf.FieldOptions
+	(NameCase)(0),                     // 0: gorm.NameCase
+	(SoftDeleteMode)(0),               // 1: gorm.SoftDeleteMode
+	(SerializeFormat)(0),              // 2: gorm.SerializeFormat
+	(EnumStorage)(0),                  // 3: gorm.EnumStorage
+	(FieldWritePermission)(0),         // 4: gorm.FieldWritePermission
+	(*GormFileOptions)(nil),           // 5: gorm.GormFileOptions
+	(*DefaultTag)(nil),                // 6: gorm.DefaultTag
+	(*NamingStrategy)(nil),            // 7: gorm.NamingStrategy
+	(*GormMessageOptions)(nil),        // 8: gorm.GormMessageOptions
+	(*IndexDefinition)(nil),           // 9: gorm.IndexDefinition
+	(*IndexColumn)(nil),               // 10: gorm.IndexColumn
+	(*ExtraField)(nil),                // 11: gorm.ExtraField
+	(*GormFieldOptions)(nil),          // 12: gorm.GormFieldOptions
+	(*GormEnumOptions)(nil),           // 13: gorm.GormEnumOptions
+	(*GormTag)(nil),                   // 14: gorm.GormTag
+	(*HasOneOptions)(nil),             // 15: gorm.HasOneOptions
+	(*BelongsToOptions)(nil),          // 16: gorm.BelongsToOptions
+	(*HasManyOptions)(nil),            // 17: gorm.HasManyOptions
+	(*ManyToManyOptions)(nil),         // 18: gorm.ManyToManyOptions
+	(*AutoServerOptions)(nil),         // 19: gorm.AutoServerOptions
+	(*MethodOptions)(nil),             // 20: gorm.MethodOptions
+	(*descriptor.FileOptions)(nil),    // 21: google.protobuf.FileOptions
+	(*descriptor.MessageOptions)(nil), // 22: google.protobuf.MessageOptions
+	(*descriptor.FieldOptions)(nil),   // 23: google.protobuf.FieldOptions
+	(*descriptor.EnumOptions)(nil),    // 24: google.protobuf.EnumOptions
+	(*descriptor.ServiceOptions)(nil), // 25: google.protobuf.ServiceOptions
+	(*descriptor.MethodOptions)(nil),  // 26: google.protobuf.MethodOptions
 }
 var file_options_gorm_proto_depIdxs = []int32{
-	3,  // 0: gorm.GormMessageOptions.include:type_name -> gorm.ExtraField
-	5,  // 1: gorm.ExtraField.tag:type_name -> gorm.GormTag
-	5,  // 2: gorm.GormFieldOptions.tag:type_name -> gorm.GormTag
-	6,  // 3: gorm.GormFieldOptions.has_one:type_name -> gorm.HasOneOptions
-	7,  // 4: gorm.GormFieldOptions.belongs_to:type_name -> gorm.BelongsToOptions
-	8,  // 5: gorm.GormFieldOptions.has_many:type_name -> gorm.HasManyOptions
-	9,  // 6: gorm.GormFieldOptions.many_to_many:type_name -> gorm.ManyToManyOptions
-	0,  // 7: gorm.GormTag.writePermission:type_name -> gorm.FieldWritePermission
-	5,  // 8: gorm.HasOneOptions.foreign_key_tag:type_name -> gorm.GormTag
-	5,  // 9: gorm.BelongsToOptions.foreign_key_tag:type_name -> gorm.GormTag
-	5,  // 10: gorm.HasManyOptions.foreign_key_tag:type_name -> gorm.GormTag
-	10, // 11: gorm.file_opts:extendee -> google.protobuf.FileOptions
-	11, // 12: gorm.opts:extendee -> google.protobuf.MessageOptions
-	12, // 13: gorm.field:extendee -> google.protobuf.FieldOptions
-	1,  // 14: gorm.file_opts:type_name -> gorm.GormFileOptions
-	2,  // 15: gorm.opts:type_name -> gorm.GormMessageOptions
-	4,  // 16: gorm.field:type_name -> gorm.GormFieldOptions
-	17, // [17:17] is the sub-list for method output_type
-	17, // [17:17] is the sub-list for method input_type
-	14, // [14:17] is the sub-list for extension type_name
-	11, // [11:14] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	7,  // 0: gorm.GormFileOptions.naming:type_name -> gorm.NamingStrategy
+	3,  // 1: gorm.GormFileOptions.enum_storage:type_name -> gorm.EnumStorage
+	6,  // 2: gorm.GormFileOptions.default_tags:type_name -> gorm.DefaultTag
+	14, // 3: gorm.DefaultTag.tag:type_name -> gorm.GormTag
+	0,  // 4: gorm.NamingStrategy.case:type_name -> gorm.NameCase
+	0,  // 5: gorm.NamingStrategy.column_case:type_name -> gorm.NameCase
+	11, // 6: gorm.GormMessageOptions.include:type_name -> gorm.ExtraField
+	9,  // 7: gorm.GormMessageOptions.indexes:type_name -> gorm.IndexDefinition
+	1,  // 8: gorm.GormMessageOptions.soft_delete_mode:type_name -> gorm.SoftDeleteMode
+	10, // 9: gorm.IndexDefinition.columns:type_name -> gorm.IndexColumn
+	14, // 10: gorm.ExtraField.tag:type_name -> gorm.GormTag
+	14, // 11: gorm.GormFieldOptions.tag:type_name -> gorm.GormTag
+	15, // 12: gorm.GormFieldOptions.has_one:type_name -> gorm.HasOneOptions
+	16, // 13: gorm.GormFieldOptions.belongs_to:type_name -> gorm.BelongsToOptions
+	17, // 14: gorm.GormFieldOptions.has_many:type_name -> gorm.HasManyOptions
+	18, // 15: gorm.GormFieldOptions.many_to_many:type_name -> gorm.ManyToManyOptions
+	2,  // 16: gorm.GormFieldOptions.serialize:type_name -> gorm.SerializeFormat
+	3,  // 17: gorm.GormFieldOptions.enum_storage:type_name -> gorm.EnumStorage
+	3,  // 18: gorm.GormEnumOptions.storage:type_name -> gorm.EnumStorage
+	4,  // 19: gorm.GormTag.writePermission:type_name -> gorm.FieldWritePermission
+	14, // 20: gorm.HasOneOptions.foreign_key_tag:type_name -> gorm.GormTag
+	14, // 21: gorm.BelongsToOptions.foreign_key_tag:type_name -> gorm.GormTag
+	14, // 22: gorm.HasManyOptions.foreign_key_tag:type_name -> gorm.GormTag
+	21, // 23: gorm.file_opts:extendee -> google.protobuf.FileOptions
+	22, // 24: gorm.opts:extendee -> google.protobuf.MessageOptions
+	23, // 25: gorm.field:extendee -> google.protobuf.FieldOptions
+	24, // 26: gorm.enum:extendee -> google.protobuf.EnumOptions
+	25, // 27: gorm.server:extendee -> google.protobuf.ServiceOptions
+	26, // 28: gorm.method:extendee -> google.protobuf.MethodOptions
+	5,  // 29: gorm.file_opts:type_name -> gorm.GormFileOptions
+	8,  // 30: gorm.opts:type_name -> gorm.GormMessageOptions
+	12, // 31: gorm.field:type_name -> gorm.GormFieldOptions
+	13, // 32: gorm.enum:type_name -> gorm.GormEnumOptions
+	19, // 33: gorm.server:type_name -> gorm.AutoServerOptions
+	20, // 34: gorm.method:type_name -> gorm.MethodOptions
+	35, // [35:35] is the sub-list for method output_type
+	35, // [35:35] is the sub-list for method input_type
+	29, // [29:35] is the sub-list for extension type_name
+	23, // [23:29] is the sub-list for extension extendee
+	0,  // [0:23] is the sub-list for field type_name
 }
 
 func init() { file_options_gorm_proto_init() }
@@ -1162,7 +2205,7 @@ func file_options_gorm_proto_init() {
 			}
 		}
 		file_options_gorm_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GormMessageOptions); i {
+			switch v := v.(*DefaultTag); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1174,7 +2217,7 @@ func file_options_gorm_proto_init() {
 			}
 		}
 		file_options_gorm_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ExtraField); i {
+			switch v := v.(*NamingStrategy); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1186,7 +2229,7 @@ func file_options_gorm_proto_init() {
 			}
 		}
 		file_options_gorm_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GormFieldOptions); i {
+			switch v := v.(*GormMessageOptions); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1198,7 +2241,7 @@ func file_options_gorm_proto_init() {
 			}
 		}
 		file_options_gorm_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GormTag); i {
+			switch v := v.(*IndexDefinition); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1210,7 +2253,7 @@ func file_options_gorm_proto_init() {
 			}
 		}
 		file_options_gorm_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*HasOneOptions); i {
+			switch v := v.(*IndexColumn); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1222,7 +2265,7 @@ func file_options_gorm_proto_init() {
 			}
 		}
 		file_options_gorm_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BelongsToOptions); i {
+			switch v := v.(*ExtraField); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1234,7 +2277,7 @@ func file_options_gorm_proto_init() {
 			}
 		}
 		file_options_gorm_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*HasManyOptions); i {
+			switch v := v.(*GormFieldOptions); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1246,6 +2289,66 @@ func file_options_gorm_proto_init() {
 			}
 		}
 		file_options_gorm_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GormEnumOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_options_gorm_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GormTag); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_options_gorm_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HasOneOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_options_gorm_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BelongsToOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_options_gorm_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HasManyOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_options_gorm_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ManyToManyOptions); i {
 			case 0:
 				return &v.state
@@ -1257,8 +2360,32 @@ func file_options_gorm_proto_init() {
 				return nil
 			}
 		}
+		file_options_gorm_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AutoServerOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_options_gorm_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MethodOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
-	file_options_gorm_proto_msgTypes[3].OneofWrappers = []interface{}{
+	file_options_gorm_proto_msgTypes[7].OneofWrappers = []interface{}{
 		(*GormFieldOptions_HasOne)(nil),
 		(*GormFieldOptions_BelongsTo)(nil),
 		(*GormFieldOptions_HasMany)(nil),
@@ -1269,9 +2396,9 @@ func file_options_gorm_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_options_gorm_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   9,
-			NumExtensions: 3,
+			NumEnums:      5,
+			NumMessages:   16,
+			NumExtensions: 6,
 			NumServices:   0,
 		},
 		GoTypes:           file_options_gorm_proto_goTypes,
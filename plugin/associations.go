@@ -1,20 +1,19 @@
 package plugin
 
 import (
-	"fmt"
 	"strings"
 
 	"github.com/gogo/protobuf/protoc-gen-gogo/generator"
 	jgorm "github.com/jinzhu/gorm"
 	"github.com/jinzhu/inflection"
 	pgs "github.com/lyft/protoc-gen-star"
+	"google.golang.org/protobuf/proto"
 
 	gorm "github.com/TheSDTM/protoc-gen-gorm/options"
 )
 
 func (p *OrmPlugin) parseAssociations(msg pgs.Message) {
-	typeName := generator.CamelCase(msg.Name().String())
-	ormable := p.getOrmable(typeName)
+	ormable := p.getOrmable(msg)
 	for _, field := range msg.Fields() {
 		fieldName := generator.CamelCase(string(field.Name()))
 		fieldOpts := getFieldOptions(field)
@@ -25,18 +24,19 @@ func (p *OrmPlugin) parseAssociations(msg pgs.Message) {
 		fieldType = strings.Trim(fieldType, "[]*")
 		parts := strings.Split(fieldType, ".")
 		fieldTypeShort := parts[len(parts)-1]
-		if p.isOrmable(fieldType) {
+		if assocOrmable := p.fieldOrmable(field); assocOrmable != nil {
 			if fieldOpts == nil {
 				fieldOpts = &gorm.GormFieldOptions{}
 			}
-			assocOrmable := p.getOrmable(fieldType)
+			// the ORM type of another package is qualified with its import
+			assocType := strings.TrimPrefix(p.fieldTypeName(field), "[]") + "ORM"
 			if field.Type().IsRepeated() {
 				if fieldOpts.GetManyToMany() != nil {
 					p.parseManyToMany(msg, ormable, fieldName, fieldTypeShort, assocOrmable, fieldOpts)
 				} else {
 					p.parseHasMany(msg, ormable, fieldName, fieldTypeShort, assocOrmable, fieldOpts)
 				}
-				fieldType = fmt.Sprintf("[]*%sORM", fieldType)
+				fieldType = "[]*" + assocType
 			} else {
 				isEmbedded := false
 				if tag := fieldOpts.GetTag(); tag != nil && tag.Embedded != nil && *tag.Embedded {
@@ -49,10 +49,8 @@ func (p *OrmPlugin) parseAssociations(msg pgs.Message) {
 						p.parseHasOne(msg, ormable, fieldName, fieldTypeShort, assocOrmable, fieldOpts)
 					}
 				}
-				fieldType = fmt.Sprintf("*%sORM", fieldType)
+				fieldType = "*" + assocType
 			}
-			// Register type used, in case it's an imported type from another package
-			// p.GetFileImports().typesToRegister = append(p.GetFileImports().typesToRegister, field.GetTypeName()) // TODO perfilov
 			ormable.Fields[fieldName] = &Field{Type: fieldType, GormFieldOptions: fieldOpts}
 			ormable.FieldsOrder = append(ormable.FieldsOrder, fieldName)
 		}
@@ -110,28 +108,21 @@ func (p *OrmPlugin) countManyToManyAssociationDimension(msg pgs.Message, typeNam
 	return dim
 }
 
-func (p *OrmPlugin) resolveAliasName(goType string, goPackage string, pgs pgs.File) string {
-	// originFile := p.currentFile
-	// p.setFile(file)
-	isPointer := strings.HasPrefix(goType, "*")
+// resolveAliasName qualifies a foreign key type with the alias of its package
+// in the file of the ormable type the key is added to
+func (p *OrmPlugin) resolveAliasName(goType string, goPackage string, file pgs.File) string {
 	typeParts := strings.Split(goType, ".")
-	if len(typeParts) == 2 {
-		var newType string
-		if strings.Contains(goPackage, "github.com") {
-			// newType = p.Import(goPackage) + "." + typeParts[1] // TODO perfilov
-			newType = goPackage + "." + typeParts[1]
-		} else {
-			// p.UsingGoImports(goPackage) // TODO perfilov
-			packageParts := strings.Split(goPackage, "/")
-			newType = packageParts[len(packageParts)-1] + "." + typeParts[1]
-		}
-		if isPointer {
-			return "*" + newType
-		}
-		return newType
+	if len(typeParts) != 2 || goPackage == "" {
+		return goType
+	}
+	originFile := p.currentFile
+	p.currentFile = file
+	defer func() { p.currentFile = originFile }()
+	newType := p.qualifier(goPackage) + "." + typeParts[1]
+	if strings.HasPrefix(goType, "*") {
+		return "*" + newType
 	}
-	// p.setFile(originFile)
-	return goType
+	return newType
 }
 
 func (p *OrmPlugin) sameType(field1 *Field, field2 *Field) bool {
@@ -149,117 +140,51 @@ func (p *OrmPlugin) sameType(field1 *Field, field2 *Field) bool {
 }
 
 func (p *OrmPlugin) parseHasMany(msg pgs.Message, parent *OrmableType, fieldName string, fieldType string, child *OrmableType, opts *gorm.GormFieldOptions) {
-	typeName := generator.CamelCase(msg.Name().String())
+	typeName := p.TypeName(msg)
 	hasMany := opts.GetHasMany()
 	if hasMany == nil {
 		hasMany = &gorm.HasManyOptions{}
 		opts.Association = &gorm.GormFieldOptions_HasMany{hasMany}
 	}
-	var assocKey *Field
-	var assocKeyName string
-	if assocKeyName = generator.CamelCase(hasMany.GetReferences()); assocKeyName == "" {
-		assocKeyName, assocKey = p.findPrimaryKey(parent)
-	} else {
-		var ok bool
-		assocKey, ok = parent.Fields[assocKeyName]
-		if !ok {
-			p.Fail("Missing", assocKeyName, "field in", parent.Name, ".")
-		}
-	}
-	hasMany.References = &assocKeyName
-	var foreignKeyType string
-	if hasMany.GetForeignKeyTag().GetNotNull() {
-		foreignKeyType = strings.TrimPrefix(assocKey.Type, "*")
-	} else if strings.HasPrefix(assocKey.Type, "*") {
-		foreignKeyType = assocKey.Type
-	} else if strings.Contains(assocKey.Type, "[]byte") {
-		foreignKeyType = assocKey.Type
-	} else {
-		foreignKeyType = "*" + assocKey.Type
-	}
-	foreignKeyType = p.resolveAliasName(foreignKeyType, assocKey.Package, child.File)
-	foreignKey := &Field{Type: foreignKeyType, Package: assocKey.Package, GormFieldOptions: &gorm.GormFieldOptions{Tag: hasMany.GetForeignKeyTag()}}
-	var foreignKeyName string
-	if foreignKeyName = hasMany.GetForeignKey(); foreignKeyName == "" {
-		if p.countHasAssociationDimension(msg, fieldType) == 1 {
-			foreignKeyName = fmt.Sprintf(typeName + assocKeyName)
-		} else {
-			foreignKeyName = fmt.Sprintf(fieldName + typeName + assocKeyName)
-		}
-	}
-	hasMany.ForeignKey = &foreignKeyName
-	if _, ok := child.Fields[foreignKeyName]; child.File.Package().ProtoName() != parent.File.Package().ProtoName() && !ok {
-		p.Fail(`Object`, child.Name, `from package`, child.File.Package().ProtoName(), `cannot be used for has-many in`, parent.File.Package().ProtoName(), `since it`,
-			`does not have FK`, foreignKeyName, `defined. Manually define the key, or switch to many-to-many`)
-	}
-	if exField, ok := child.Fields[foreignKeyName]; !ok {
-		child.Fields[foreignKeyName] = foreignKey
-		child.FieldsOrder = append(child.FieldsOrder, foreignKeyName)
-	} else {
-		if exField.Type == "interface{}" {
-			exField.Type = foreignKey.Type
-		} else if !p.sameType(exField, foreignKey) {
-			p.Fail("Cannot include", foreignKeyName, "field into", child.Name, "as it already exists there with a different type:", exField.Type, foreignKey.Type)
+	assocKeyNames, assocKeys := p.referencedKeys(parent, hasMany.GetReferences())
+	prefix := typeName
+	if p.countHasAssociationDimension(msg, fieldType) != 1 {
+		prefix = fieldName + typeName
+	}
+	foreignKeyNames := p.foreignKeyNames(parent, fieldName, hasMany.GetForeignKey(), prefix, assocKeyNames)
+	references, foreignKeys := strings.Join(assocKeyNames, ","), strings.Join(foreignKeyNames, ",")
+	hasMany.References, hasMany.ForeignKey = &references, &foreignKeys
+	for i, foreignKeyName := range foreignKeyNames {
+		if child.File.Package() != parent.File.Package() && !p.targets[child.File] {
+			p.checkDeclaredForeignKey(child, parent, foreignKeyName, assocKeys[i], "has-many", "many-to-many")
+			continue
 		}
+		p.addForeignKey(child, parent, foreignKeyName, assocKeys[i], hasMany.GetForeignKeyTag())
 	}
-	child.Fields[foreignKeyName].ParentOriginName = parent.OriginName
 }
 
 func (p *OrmPlugin) parseHasOne(msg pgs.Message, parent *OrmableType, fieldName string, fieldType string, child *OrmableType, opts *gorm.GormFieldOptions) {
-	typeName := generator.CamelCase(msg.Name().String())
+	typeName := p.TypeName(msg)
 	hasOne := opts.GetHasOne()
 	if hasOne == nil {
 		hasOne = &gorm.HasOneOptions{}
 		opts.Association = &gorm.GormFieldOptions_HasOne{hasOne}
 	}
-	var assocKey *Field
-	var assocKeyName string
-	if assocKeyName = generator.CamelCase(hasOne.GetReferences()); assocKeyName == "" {
-		assocKeyName, assocKey = p.findPrimaryKey(parent)
-	} else {
-		var ok bool
-		assocKey, ok = parent.Fields[assocKeyName]
-		if !ok {
-			p.Fail("Missing", assocKeyName, "field in", parent.Name, ".")
-		}
-	}
-	hasOne.References = &assocKeyName
-	var foreignKeyType string
-	if hasOne.GetForeignKeyTag().GetNotNull() {
-		foreignKeyType = strings.TrimPrefix(assocKey.Type, "*")
-	} else if strings.HasPrefix(assocKey.Type, "*") {
-		foreignKeyType = assocKey.Type
-	} else if strings.Contains(assocKey.Type, "[]byte") {
-		foreignKeyType = assocKey.Type
-	} else {
-		foreignKeyType = "*" + assocKey.Type
-	}
-	foreignKeyType = p.resolveAliasName(foreignKeyType, assocKey.Package, child.File)
-	foreignKey := &Field{Type: foreignKeyType, Package: assocKey.Package, GormFieldOptions: &gorm.GormFieldOptions{Tag: hasOne.GetForeignKeyTag()}}
-	var foreignKeyName string
-	if foreignKeyName = generator.CamelCase(hasOne.GetForeignKey()); foreignKeyName == "" {
-		if p.countHasAssociationDimension(msg, fieldType) == 1 {
-			foreignKeyName = fmt.Sprintf(typeName + assocKeyName)
-		} else {
-			foreignKeyName = fmt.Sprintf(fieldName + typeName + assocKeyName)
-		}
-	}
-	hasOne.ForeignKey = &foreignKeyName
-	if _, ok := child.Fields[foreignKeyName]; child.File.Package().ProtoName() != parent.File.Package().ProtoName() && !ok {
-		p.Fail(`Object`, child.Name, `from package`, child.File.Package().ProtoName(), `cannot be used for has-one in`, parent.Name, `since it`,
-			`does not have FK field`, foreignKeyName, `defined. Manually define the key, or switch to belongs-to`)
-	}
-	if exField, ok := child.Fields[foreignKeyName]; !ok {
-		child.Fields[foreignKeyName] = foreignKey
-		child.FieldsOrder = append(child.FieldsOrder, foreignKeyName)
-	} else {
-		if exField.Type == "interface{}" {
-			exField.Type = foreignKey.Type
-		} else if !p.sameType(exField, foreignKey) {
-			p.Fail("Cannot include", foreignKeyName, "field into", child.Name, "as it already exists there with a different type:", exField.Type, foreignKey.Type)
+	assocKeyNames, assocKeys := p.referencedKeys(parent, hasOne.GetReferences())
+	prefix := typeName
+	if p.countHasAssociationDimension(msg, fieldType) != 1 {
+		prefix = fieldName + typeName
+	}
+	foreignKeyNames := p.foreignKeyNames(parent, fieldName, hasOne.GetForeignKey(), prefix, assocKeyNames)
+	references, foreignKeys := strings.Join(assocKeyNames, ","), strings.Join(foreignKeyNames, ",")
+	hasOne.References, hasOne.ForeignKey = &references, &foreignKeys
+	for i, foreignKeyName := range foreignKeyNames {
+		if child.File.Package() != parent.File.Package() && !p.targets[child.File] {
+			p.checkDeclaredForeignKey(child, parent, foreignKeyName, assocKeys[i], "has-one", "belongs-to")
+			continue
 		}
+		p.addForeignKey(child, parent, foreignKeyName, assocKeys[i], hasOne.GetForeignKeyTag())
 	}
-	child.Fields[foreignKeyName].ParentOriginName = parent.OriginName
 }
 
 func (p *OrmPlugin) parseBelongsTo(msg pgs.Message, child *OrmableType, fieldName string, fieldType string, parent *OrmableType, opts *gorm.GormFieldOptions) {
@@ -268,20 +193,79 @@ func (p *OrmPlugin) parseBelongsTo(msg pgs.Message, child *OrmableType, fieldNam
 		belongsTo = &gorm.BelongsToOptions{}
 		opts.Association = &gorm.GormFieldOptions_BelongsTo{belongsTo}
 	}
-	var assocKey *Field
-	var assocKeyName string
-	if assocKeyName = generator.CamelCase(belongsTo.GetReferences()); assocKeyName == "" {
-		assocKeyName, assocKey = p.findPrimaryKey(parent)
-	} else {
-		var ok bool
-		assocKey, ok = parent.Fields[assocKeyName]
+	assocKeyNames, assocKeys := p.referencedKeys(parent, belongsTo.GetReferences())
+	prefix := fieldType
+	if p.countBelongsToAssociationDimension(msg, fieldType) != 1 {
+		prefix = fieldName
+	}
+	foreignKeyNames := p.foreignKeyNames(child, fieldName, belongsTo.GetForeignKey(), prefix, assocKeyNames)
+	references, foreignKeys := strings.Join(assocKeyNames, ","), strings.Join(foreignKeyNames, ",")
+	belongsTo.References, belongsTo.ForeignKey = &references, &foreignKeys
+	for i, foreignKeyName := range foreignKeyNames {
+		p.addForeignKey(child, parent, foreignKeyName, assocKeys[i], belongsTo.GetForeignKeyTag())
+	}
+}
+
+// referencedKeys returns the names and the fields of the keys of an ormable
+// type referenced by an association, its primary keys unless the comma
+// separated references name them
+func (p *OrmPlugin) referencedKeys(ormable *OrmableType, references string) ([]string, []*Field) {
+	names := keyNames(references)
+	if len(names) == 0 {
+		if names = p.primaryKeys(ormable); len(names) == 0 {
+			p.Fail("Primary key cannot be found in", ormable.Name, ".")
+		}
+	}
+	fields := make([]*Field, len(names))
+	for i, name := range names {
+		field, ok := ormable.Fields[name]
 		if !ok {
-			p.Fail("Missing", assocKeyName, "field in", parent.Name, ".")
+			p.Fail("Missing", name, "field in", ormable.Name, ".")
 		}
+		fields[i] = field
 	}
-	belongsTo.References = &assocKeyName
+	return names, fields
+}
+
+// foreignKeyNames returns the foreign keys of the association fieldName of an
+// ormable type, one per referenced key: the comma separated foreign keys or
+// the key names with the prefix
+func (p *OrmPlugin) foreignKeyNames(ormable *OrmableType, fieldName string, foreignKeys string, prefix string, keys []string) []string {
+	names := keyNames(foreignKeys)
+	if len(names) == 0 {
+		for _, key := range keys {
+			names = append(names, prefix+key)
+		}
+	}
+	if len(names) != len(keys) {
+		p.Failf("the association %s of %s has %d foreign keys for the %d keys %s it references",
+			fieldName, ormable.Name, len(names), len(keys), strings.Join(keys, ", "))
+	}
+	return names
+}
+
+// checkDeclaredForeignKey fails unless the child type of a has-one or has-many
+// association from another package declares the foreign key itself, unless
+// the child type is generated by this invocation and gets the key added.
+func (p *OrmPlugin) checkDeclaredForeignKey(child *OrmableType, parent *OrmableType, foreignKeyName string, assocKey *Field, association string, alternative string) {
+	exField, ok := child.Fields[foreignKeyName]
+	if !ok {
+		p.Failf("object %s from package %s cannot be used for %s in %s since it does not declare the FK field %s. Define the key in %s, or switch to %s",
+			child.Name, child.File.Package().ProtoName(), association, parent.Name, foreignKeyName, child.Name, alternative)
+	}
+	// the declared key may be nullable or not
+	declared, expected := *exField, *p.foreignKeyField(child, assocKey, nil)
+	declared.Type, expected.Type = strings.TrimPrefix(declared.Type, "*"), strings.TrimPrefix(expected.Type, "*")
+	if declared.Type != "interface{}" && !p.sameType(&declared, &expected) {
+		p.Failf("the FK field %s of %s has the type %s, %s expects %s", foreignKeyName, child.Name, exField.Type, parent.Name, expected.Type)
+	}
+}
+
+// foreignKeyField returns the foreign key field of the child type of an
+// association pointing to a key of the parent type
+func (p *OrmPlugin) foreignKeyField(child *OrmableType, assocKey *Field, tag *gorm.GormTag) *Field {
 	var foreignKeyType string
-	if belongsTo.GetForeignKeyTag().GetNotNull() {
+	if tag.GetNotNull() {
 		foreignKeyType = strings.TrimPrefix(assocKey.Type, "*")
 	} else if strings.HasPrefix(assocKey.Type, "*") {
 		foreignKeyType = assocKey.Type
@@ -291,22 +275,23 @@ func (p *OrmPlugin) parseBelongsTo(msg pgs.Message, child *OrmableType, fieldNam
 		foreignKeyType = "*" + assocKey.Type
 	}
 	foreignKeyType = p.resolveAliasName(foreignKeyType, assocKey.Package, child.File)
-	foreignKey := &Field{Type: foreignKeyType, Package: assocKey.Package, GormFieldOptions: &gorm.GormFieldOptions{Tag: belongsTo.GetForeignKeyTag()}}
-	var foreignKeyName string
-	if foreignKeyName = generator.CamelCase(belongsTo.GetForeignKey()); foreignKeyName == "" {
-		if p.countBelongsToAssociationDimension(msg, fieldType) == 1 {
-			foreignKeyName = fmt.Sprintf(fieldType + assocKeyName)
-		} else {
-			foreignKeyName = fmt.Sprintf(fieldName + assocKeyName)
-		}
+	if tag != nil {
+		// the keys of a composite foreign key are named separately
+		tag = proto.Clone(tag).(*gorm.GormTag)
 	}
-	belongsTo.ForeignKey = &foreignKeyName
+	return &Field{Type: foreignKeyType, Package: assocKey.Package, GormFieldOptions: &gorm.GormFieldOptions{Tag: tag}}
+}
+
+// addForeignKey gives the child type of an association the foreign key
+// pointing to a key of the parent type, unless it has the field already
+func (p *OrmPlugin) addForeignKey(child *OrmableType, parent *OrmableType, foreignKeyName string, assocKey *Field, tag *gorm.GormTag) {
+	foreignKey := p.foreignKeyField(child, assocKey, tag)
 	if exField, ok := child.Fields[foreignKeyName]; !ok {
 		child.Fields[foreignKeyName] = foreignKey
 		child.FieldsOrder = append(child.FieldsOrder, foreignKeyName)
 	} else {
 		if exField.Type == "interface{}" {
-			exField.Type = foreignKeyType
+			exField.Type = foreignKey.Type
 		} else if !p.sameType(exField, foreignKey) {
 			p.Fail("Cannot include", foreignKeyName, "field into", child.Name, "as it already exists there with a different type:", exField.Type, foreignKey.Type)
 		}
@@ -314,36 +299,31 @@ func (p *OrmPlugin) parseBelongsTo(msg pgs.Message, child *OrmableType, fieldNam
 	child.Fields[foreignKeyName].ParentOriginName = parent.OriginName
 }
 
+// keyNames returns the field names of a comma separated list of keys
+func keyNames(keys string) []string {
+	var names []string
+	for _, key := range strings.Split(keys, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			names = append(names, generator.CamelCase(key))
+		}
+	}
+	return names
+}
+
 func (p *OrmPlugin) parseManyToMany(msg pgs.Message, ormable *OrmableType, fieldName string, fieldType string, assoc *OrmableType, opts *gorm.GormFieldOptions) {
-	typeName := generator.CamelCase(msg.Name().String())
+	typeName := p.TypeName(msg)
 	mtm := opts.GetManyToMany()
 	if mtm == nil {
 		mtm = &gorm.ManyToManyOptions{}
 		opts.Association = &gorm.GormFieldOptions_ManyToMany{mtm}
 	}
 
-	var foreignKeyName string
-	if foreignKeyName = generator.CamelCase(mtm.GetForeignKey()); foreignKeyName == "" {
-		foreignKeyName, _ = p.findPrimaryKey(ormable)
-	} else {
-		var ok bool
-		_, ok = ormable.Fields[foreignKeyName]
-		if !ok {
-			p.Fail("Missing", foreignKeyName, "field in", ormable.Name, ".")
-		}
-	}
-	mtm.ForeignKey = &foreignKeyName
-	var assocKeyName string
-	if assocKeyName = generator.CamelCase(mtm.GetReferences()); assocKeyName == "" {
-		assocKeyName, _ = p.findPrimaryKey(assoc)
-	} else {
-		var ok bool
-		_, ok = assoc.Fields[assocKeyName]
-		if !ok {
-			p.Fail("Missing", assocKeyName, "field in", assoc.Name, ".")
-		}
-	}
-	mtm.References = &assocKeyName
+	foreignKeyNames, _ := p.referencedKeys(ormable, mtm.GetForeignKey())
+	foreignKeys := strings.Join(foreignKeyNames, ",")
+	mtm.ForeignKey = &foreignKeys
+	assocKeyNames, _ := p.referencedKeys(assoc, mtm.GetReferences())
+	references := strings.Join(assocKeyNames, ",")
+	mtm.References = &references
 	var jt string
 	if jt = jgorm.ToDBName(mtm.GetJointable()); jt == "" {
 		if p.countManyToManyAssociationDimension(msg, fieldType) == 1 && typeName != fieldType {
@@ -353,47 +333,45 @@ func (p *OrmPlugin) parseManyToMany(msg pgs.Message, ormable *OrmableType, field
 		}
 	}
 	mtm.Jointable = &jt
-	var jtForeignKey string
-	if jtForeignKey = generator.CamelCase(mtm.GetJoinForeignKey()); jtForeignKey == "" {
-		jtForeignKey = jgorm.ToDBName(typeName + foreignKeyName)
+	jtForeignKeys := p.foreignKeyNames(ormable, fieldName, mtm.GetJoinForeignKey(), typeName, foreignKeyNames)
+	for i, key := range jtForeignKeys {
+		jtForeignKeys[i] = jgorm.ToDBName(key)
 	}
+	jtForeignKey := strings.Join(jtForeignKeys, ",")
 	mtm.JoinForeignKey = &jtForeignKey
-	var jtAssocForeignKey string
-	if jtAssocForeignKey = generator.CamelCase(mtm.GetJoinReferences()); jtAssocForeignKey == "" {
-		if typeName == fieldType {
-			jtAssocForeignKey = jgorm.ToDBName(inflection.Singular(fieldName) + assocKeyName)
-		} else {
-			jtAssocForeignKey = jgorm.ToDBName(fieldType + assocKeyName)
-		}
+	prefix := fieldType
+	if typeName == fieldType {
+		prefix = inflection.Singular(fieldName)
 	}
+	jtAssocForeignKeys := p.foreignKeyNames(ormable, fieldName, mtm.GetJoinReferences(), prefix, assocKeyNames)
+	for i, key := range jtAssocForeignKeys {
+		jtAssocForeignKeys[i] = jgorm.ToDBName(key)
+	}
+	jtAssocForeignKey := strings.Join(jtAssocForeignKeys, ",")
 	mtm.JoinReferences = &jtAssocForeignKey
 }
 
-func (p *OrmPlugin) findPrimaryKey(ormable *OrmableType) (string, *Field) {
-	for fieldName, field := range ormable.Fields {
-		if field.GetTag().GetPrimaryKey() {
-			return fieldName, field
+// primaryKeys returns the primary key fields of an ormable type in field
+// order, the fields tagged as primary key or else its Id field
+func (p *OrmPlugin) primaryKeys(ormable *OrmableType) []string {
+	var keys, ids []string
+	for _, fieldName := range ormable.FieldsOrder {
+		if ormable.Fields[fieldName].GetTag().GetPrimaryKey() {
+			keys = append(keys, fieldName)
 		}
-	}
-	for fieldName, field := range ormable.Fields {
 		if strings.ToLower(fieldName) == "id" {
-			return fieldName, field
+			ids = append(ids, fieldName)
 		}
 	}
-	p.Fail("Primary key cannot be found in", ormable.Name, ".")
-	return "", nil
+	if len(keys) > 0 {
+		return keys
+	}
+	if len(ids) > 1 {
+		p.Failf("the primary key of %s is ambiguous between %s, tag one of them as primary key", ormable.Name, strings.Join(ids, " and "))
+	}
+	return ids
 }
 
 func (p *OrmPlugin) hasPrimaryKey(ormable *OrmableType) bool {
-	for _, field := range ormable.Fields {
-		if field.GetTag().GetPrimaryKey() {
-			return true
-		}
-	}
-	for fieldName, _ := range ormable.Fields {
-		if strings.ToLower(fieldName) == "id" {
-			return true
-		}
-	}
-	return false
+	return len(p.primaryKeys(ormable)) > 0
 }
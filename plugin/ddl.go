@@ -0,0 +1,435 @@
+package plugin
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gogo/protobuf/protoc-gen-gogo/generator"
+	pgs "github.com/lyft/protoc-gen-star"
+
+	gorm "github.com/TheSDTM/protoc-gen-gorm/options"
+)
+
+// ddlDialect describes the SQL DDL of a DB engine
+type ddlDialect struct {
+	// columnTypes are the column types of the Go types of the ORM fields
+	// without a type tag
+	columnTypes map[string]string
+	// sizedString is the column type of the strings with a size tag given
+	// the size %d, keyString the one of the unsized strings in keys and
+	// indexes if the engine cannot index its default string type
+	sizedString string
+	keyString   string
+	// autoIncrement declares the auto incremented integer column type %s
+	autoIncrement string
+	// quote encloses the identifier %s
+	quote string
+	// inlineForeignKeys declares the foreign keys in the CREATE TABLE
+	// statements, the engine cannot add them with ALTER TABLE
+	inlineForeignKeys bool
+	// indexType creates the index %[1]s of the table %[2]s with the method
+	// %[3]s
+	indexType string
+	// indexClasses, indexInclude and partialIndexes tell whether the engine
+	// creates the FULLTEXT or SPATIAL indexes, the indexes storing columns
+	// and the partial indexes
+	indexClasses   bool
+	indexInclude   bool
+	partialIndexes bool
+
+	// The statements below migrate a table, given its quoted name %[1]s.
+	// An empty statement is not supported by the engine.
+
+	// addColumn adds the column definition %[2]s
+	addColumn string
+	// renameColumn renames the quoted column %[2]s to %[3]s, or the
+	// unquoted column %[5]s of the unquoted table %[4]s to %[6]s
+	renameColumn string
+	// dropIndex drops the schema qualified index %[1]s of the quoted table
+	// %[2]s, %[3]s is the quoted index
+	dropIndex string
+	// dropForeignKey drops the constraint %[2]s
+	dropForeignKey string
+	// addEnumValue adds the value %[2]s to the native enum type %[1]s
+	addEnumValue string
+	// modifyColumn redefines a column with its definition %[2]s
+	modifyColumn string
+	// alterType changes the type of the column %[2]s to %[3]s, the type
+	// includes the nullability if alterNullability is set
+	alterType        string
+	alterNullability bool
+	// setNotNull and dropNotNull change the nullability of the column %[2]s
+	setNotNull  string
+	dropNotNull string
+	// setDefault and dropDefault change the default %[3]s of the column %[2]s
+	setDefault  string
+	dropDefault string
+}
+
+var ddlDialects = map[int]*ddlDialect{
+	ENGINE_POSTGRES: {
+		columnTypes: map[string]string{
+			"bool": "boolean", "int": "bigint", "int32": "integer", "int64": "bigint",
+			"uint": "bigint", "uint32": "bigint", "uint64": "bigint",
+			"float32": "real", "float64": "double precision", "string": "text", "[]byte": "bytea",
+			"time.Time": "timestamptz", "time.Duration": "bigint",
+		},
+		sizedString:    "varchar(%d)",
+		autoIncrement:  "%s GENERATED BY DEFAULT AS IDENTITY",
+		quote:          `"%s"`,
+		indexType:      "%[1]s ON %[2]s USING %[3]s",
+		indexInclude:   true,
+		partialIndexes: true,
+		addColumn:      "ALTER TABLE %[1]s ADD COLUMN %[2]s",
+		renameColumn:   "ALTER TABLE %[1]s RENAME COLUMN %[2]s TO %[3]s",
+		dropIndex:      "DROP INDEX %[1]s",
+		dropForeignKey: "ALTER TABLE %[1]s DROP CONSTRAINT %[2]s",
+		addEnumValue:   "ALTER TYPE %[1]s ADD VALUE %[2]s",
+		alterType:      "ALTER TABLE %[1]s ALTER COLUMN %[2]s TYPE %[3]s",
+		setNotNull:     "ALTER TABLE %[1]s ALTER COLUMN %[2]s SET NOT NULL",
+		dropNotNull:    "ALTER TABLE %[1]s ALTER COLUMN %[2]s DROP NOT NULL",
+		setDefault:     "ALTER TABLE %[1]s ALTER COLUMN %[2]s SET DEFAULT %[3]s",
+		dropDefault:    "ALTER TABLE %[1]s ALTER COLUMN %[2]s DROP DEFAULT",
+	},
+	ENGINE_MYSQL: {
+		columnTypes: map[string]string{
+			"bool": "boolean", "int": "bigint", "int32": "int", "int64": "bigint",
+			"uint": "bigint unsigned", "uint32": "int unsigned", "uint64": "bigint unsigned",
+			"float32": "float", "float64": "double", "string": "longtext", "[]byte": "longblob",
+			"time.Time": "datetime(3)", "time.Duration": "bigint",
+		},
+		sizedString:    "varchar(%d)",
+		keyString:      "varchar(191)",
+		autoIncrement:  "%s AUTO_INCREMENT",
+		quote:          "`%s`",
+		indexType:      "%[1]s USING %[3]s ON %[2]s",
+		indexClasses:   true,
+		addColumn:      "ALTER TABLE %[1]s ADD COLUMN %[2]s",
+		renameColumn:   "ALTER TABLE %[1]s RENAME COLUMN %[2]s TO %[3]s",
+		dropIndex:      "DROP INDEX %[3]s ON %[2]s",
+		dropForeignKey: "ALTER TABLE %[1]s DROP FOREIGN KEY %[2]s",
+		modifyColumn:   "ALTER TABLE %[1]s MODIFY COLUMN %[2]s",
+	},
+	ENGINE_SQLITE: {
+		columnTypes: map[string]string{
+			"bool": "numeric", "int": "integer", "int32": "integer", "int64": "integer",
+			"uint": "integer", "uint32": "integer", "uint64": "integer",
+			"float32": "real", "float64": "real", "string": "text", "[]byte": "blob",
+			"time.Time": "datetime", "time.Duration": "integer",
+		},
+		sizedString: "varchar(%d)",
+		// an integer primary key is an alias of the auto incremented rowid
+		autoIncrement:     "%s",
+		quote:             `"%s"`,
+		inlineForeignKeys: true,
+		partialIndexes:    true,
+		addColumn:         "ALTER TABLE %[1]s ADD COLUMN %[2]s",
+		renameColumn:      "ALTER TABLE %[1]s RENAME COLUMN %[2]s TO %[3]s",
+		dropIndex:         "DROP INDEX %[1]s",
+	},
+	ENGINE_SQLSERVER: {
+		columnTypes: map[string]string{
+			"bool": "bit", "int": "bigint", "int32": "int", "int64": "bigint",
+			"uint": "bigint", "uint32": "bigint", "uint64": "bigint",
+			"float32": "real", "float64": "float", "string": "nvarchar(MAX)", "[]byte": "varbinary(MAX)",
+			"time.Time": "datetimeoffset", "time.Duration": "bigint",
+		},
+		sizedString:      "nvarchar(%d)",
+		keyString:        "nvarchar(256)",
+		autoIncrement:    "%s IDENTITY(1,1)",
+		quote:            `"%s"`,
+		indexInclude:     true,
+		partialIndexes:   true,
+		addColumn:        "ALTER TABLE %[1]s ADD %[2]s",
+		renameColumn:     "EXEC sp_rename '%[4]s.%[5]s', '%[6]s', 'COLUMN'",
+		dropIndex:        "DROP INDEX %[3]s ON %[2]s",
+		dropForeignKey:   "ALTER TABLE %[1]s DROP CONSTRAINT %[2]s",
+		alterType:        "ALTER TABLE %[1]s ALTER COLUMN %[2]s %[3]s",
+		alterNullability: true,
+	},
+	ENGINE_COCKROACH: {
+		columnTypes: map[string]string{
+			"bool": "BOOL", "int": "INT8", "int32": "INT4", "int64": "INT8",
+			"uint": "INT8", "uint32": "INT8", "uint64": "INT8",
+			"float32": "FLOAT4", "float64": "FLOAT8", "string": "STRING", "[]byte": "BYTES",
+			"time.Time": "TIMESTAMPTZ", "time.Duration": "INT8",
+		},
+		sizedString: "VARCHAR(%d)",
+		// 64 bit integer primary keys default to unique_rowid()
+		autoIncrement:  "%s",
+		quote:          `"%s"`,
+		indexType:      "%[1]s ON %[2]s USING %[3]s",
+		indexInclude:   true,
+		partialIndexes: true,
+		addColumn:      "ALTER TABLE %[1]s ADD COLUMN %[2]s",
+		renameColumn:   "ALTER TABLE %[1]s RENAME COLUMN %[2]s TO %[3]s",
+		dropIndex:      "DROP INDEX %[2]s@%[3]s",
+		dropForeignKey: "ALTER TABLE %[1]s DROP CONSTRAINT %[2]s",
+		addEnumValue:   "ALTER TYPE %[1]s ADD VALUE %[2]s",
+		alterType:      "ALTER TABLE %[1]s ALTER COLUMN %[2]s TYPE %[3]s",
+		setNotNull:     "ALTER TABLE %[1]s ALTER COLUMN %[2]s SET NOT NULL",
+		dropNotNull:    "ALTER TABLE %[1]s ALTER COLUMN %[2]s DROP NOT NULL",
+		setDefault:     "ALTER TABLE %[1]s ALTER COLUMN %[2]s SET DEFAULT %[3]s",
+		dropDefault:    "ALTER TABLE %[1]s ALTER COLUMN %[2]s DROP DEFAULT",
+	},
+}
+
+// ddlColumn is a column of an ormable type, the fields of an embedded type
+// are columns of the embedding type
+type ddlColumn struct {
+	name        string
+	fieldName   string
+	field       *Field
+	embedded    bool
+	renamedFrom string
+}
+
+// generateDDL outputs a .sql file creating the tables of the ormable types of
+// the file, their indexes, foreign keys and many-to-many join tables
+func (p *OrmPlugin) generateDDL(d *ddlDialect, file pgs.File) {
+	p.setFile(file)
+	schema := p.fileSchema(d, file)
+	if len(schema.Tables) == 0 {
+		return
+	}
+	if p.dbEngine != p.ddlEngine {
+		p.Failf("the ddl engine %s differs from the engine %s of %s", engineName(p.ddlEngine), engineName(p.dbEngine), file.Name())
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "-- Code generated by protoc-gen-gorm from %s. DO NOT EDIT.\n", file.Name())
+	for _, enum := range schema.Enums {
+		fmt.Fprintf(&b, "\n%s;\n", createEnum(schema.Engine, enum))
+	}
+	for _, table := range schema.Tables {
+		fmt.Fprintf(&b, "\n%s;\n", d.createTable(table))
+		for _, index := range table.Indexes {
+			if settings := d.unsupportedSettings(index); len(settings) > 0 {
+				p.warning(`the index %s of %s is created without its %s, unsupported by this engine`, index.Name, table.Name, strings.Join(settings, " and "))
+			}
+			fmt.Fprintf(&b, "%s;\n", d.createIndex(table, index))
+		}
+	}
+	if !d.inlineForeignKeys {
+		var alters []string
+		for _, table := range schema.Tables {
+			for _, fk := range table.ForeignKeys {
+				alters = append(alters, d.addForeignKey(table, fk))
+			}
+		}
+		if len(alters) > 0 {
+			fmt.Fprintf(&b, "\n%s;\n", strings.Join(alters, ";\n"))
+		}
+	}
+	p.AddGeneratorFile(p.ctx.OutputPath(file).SetExt(".gorm.sql").String(), b.String())
+}
+
+// ddlColumns returns the columns of an ormable message in field order, the
+// columns of embedded types are prefixed with their embedded_prefix tag
+func (p *OrmPlugin) ddlColumns(msg pgs.Message, prefix string) []ddlColumn {
+	ormable := p.getOrmable(msg)
+	fields := map[string]pgs.Field{}
+	for _, field := range msg.Fields() {
+		fields[generator.CamelCase(string(field.Name()))] = field
+	}
+	var columns []ddlColumn
+	for _, fieldName := range ormable.FieldsOrder {
+		field := ormable.Fields[fieldName]
+		if field.GetTag().GetIgnore() {
+			continue
+		}
+		// associations have no column of their own
+		if strings.HasSuffix(field.Type, "ORM") {
+			if field.GetTag().GetEmbedded() {
+				for _, column := range p.ddlColumns(fieldMessage(fields[fieldName]), prefix+field.GetTag().GetEmbeddedPrefix()) {
+					column.embedded = true
+					columns = append(columns, column)
+				}
+			}
+			continue
+		}
+		column := ddlColumn{name: prefix + columnName(fieldName, field), fieldName: fieldName, field: field}
+		if field.GetRenamedFrom() != "" {
+			column.renamedFrom = prefix + field.GetRenamedFrom()
+		}
+		columns = append(columns, column)
+	}
+	return columns
+}
+
+// ddlColumnType returns the column type of a field, set by its type tag or
+// derived from its Go type
+func (p *OrmPlugin) ddlColumnType(d *ddlDialect, ormable *OrmableType, fieldName string, field *Field, keyed bool) string {
+	tag := field.GetTag()
+	if tag.GetType() != "" {
+		return tag.GetType()
+	}
+	goType := strings.TrimPrefix(field.Type, "*")
+	switch field.SoftDelete {
+	case gorm.SoftDeleteMode_DELETED_AT:
+		goType = "time.Time"
+	case gorm.SoftDeleteMode_UNIX_TIMESTAMP, gorm.SoftDeleteMode_FLAG:
+		goType = "uint"
+	}
+	if goType == "string" && tag.GetSize() > 0 {
+		return fmt.Sprintf(d.sizedString, tag.GetSize())
+	}
+	if goType == "string" && keyed && d.keyString != "" {
+		return d.keyString
+	}
+	if columnType, ok := d.columnTypes[goType]; ok {
+		return columnType
+	}
+	p.Failf("cannot tell the column type of %s.%s of type %s, set its type tag", ormable.Name, fieldName, field.Type)
+	return ""
+}
+
+// createEnum returns the statement creating a native enum type on an engine
+func createEnum(engine string, enum *SchemaEnum) string {
+	return fmt.Sprintf(dialects[engines[engine]].nativeEnumDDL, enum.Name, sqlStrings(enum.Values))
+}
+
+// createTable returns the CREATE TABLE statement of a table, with its foreign
+// keys if the engine declares them inline
+func (d *ddlDialect) createTable(table *SchemaTable) string {
+	var lines []string
+	for _, column := range table.Columns {
+		lines = append(lines, d.columnDefinition(column))
+	}
+	if len(table.PrimaryKey) > 0 {
+		lines = append(lines, fmt.Sprintf("PRIMARY KEY (%s)", d.quoteList(table.PrimaryKey)))
+	}
+	for _, check := range table.Checks {
+		lines = append(lines, d.checkClause(check))
+	}
+	if d.inlineForeignKeys {
+		for _, fk := range table.ForeignKeys {
+			lines = append(lines, d.foreignKeyClause(fk))
+		}
+	}
+	return fmt.Sprintf("CREATE TABLE %s (\n  %s\n)", d.quoteName(table.Name), strings.Join(lines, ",\n  "))
+}
+
+// columnDefinition returns the definition of a column in a CREATE TABLE or an
+// ALTER TABLE statement
+func (d *ddlDialect) columnDefinition(column *SchemaColumn) string {
+	columnType := column.Type
+	if column.AutoIncrement {
+		columnType = fmt.Sprintf(d.autoIncrement, columnType)
+	}
+	definition := d.quoteName(column.Name) + " " + columnType
+	if column.NotNull {
+		definition += " NOT NULL"
+	}
+	if column.Default != nil {
+		definition += " DEFAULT " + *column.Default
+	}
+	if column.Unique {
+		definition += " UNIQUE"
+	}
+	return definition
+}
+
+// createIndex returns the CREATE INDEX statement of an index, without the
+// settings the engine does not support
+func (d *ddlDialect) createIndex(table *SchemaTable, index *SchemaIndex) string {
+	statement := "CREATE INDEX"
+	switch {
+	case index.Class != "" && d.indexClasses:
+		statement = "CREATE " + index.Class + " INDEX"
+	case index.Unique:
+		statement = "CREATE UNIQUE INDEX"
+	}
+	target := fmt.Sprintf("%s ON %s", d.quoteName(index.Name), d.quoteName(table.Name))
+	if index.Type != "" && d.indexType != "" {
+		target = fmt.Sprintf(d.indexType, d.quoteName(index.Name), d.quoteName(table.Name), index.Type)
+	}
+	columns := make([]string, len(index.Columns))
+	for i, column := range index.Columns {
+		columns[i] = d.quoteName(column)
+		if i < len(index.Sorts) && index.Sorts[i] != "" {
+			columns[i] += " " + index.Sorts[i]
+		}
+	}
+	statement = fmt.Sprintf("%s %s (%s)", statement, target, strings.Join(columns, ", "))
+	if len(index.Include) > 0 && d.indexInclude {
+		statement += fmt.Sprintf(" INCLUDE (%s)", d.quoteList(index.Include))
+	}
+	if index.Where != "" && d.partialIndexes {
+		statement += " WHERE " + index.Where
+	}
+	return statement
+}
+
+// unsupportedSettings returns the settings of an index the engine cannot
+// create
+func (d *ddlDialect) unsupportedSettings(index *SchemaIndex) []string {
+	var settings []string
+	if index.Class != "" && !d.indexClasses {
+		settings = append(settings, "class")
+	}
+	if index.Type != "" && d.indexType == "" {
+		settings = append(settings, "type")
+	}
+	if len(index.Include) > 0 && !d.indexInclude {
+		settings = append(settings, "include")
+	}
+	if index.Where != "" && !d.partialIndexes {
+		settings = append(settings, "where")
+	}
+	return settings
+}
+
+func (d *ddlDialect) addForeignKey(table *SchemaTable, fk *SchemaForeignKey) string {
+	return fmt.Sprintf("ALTER TABLE %s ADD %s", d.quoteName(table.Name), d.foreignKeyClause(fk))
+}
+
+// foreignKeyClause returns the CONSTRAINT clause of a foreign key with its
+// referential actions
+func (d *ddlDialect) foreignKeyClause(fk *SchemaForeignKey) string {
+	clause := fmt.Sprintf("CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s (%s)",
+		d.quoteName(fk.Name), d.quoteList(fk.Columns), d.quoteName(fk.RefTable), d.quoteList(fk.RefColumns))
+	if fk.OnUpdate != "" {
+		clause += " ON UPDATE " + fk.OnUpdate
+	}
+	if fk.OnDelete != "" {
+		clause += " ON DELETE " + fk.OnDelete
+	}
+	return clause
+}
+
+func (d *ddlDialect) checkClause(check *SchemaCheck) string {
+	if check.Name != "" {
+		return fmt.Sprintf("CONSTRAINT %s CHECK (%s)", d.quoteName(check.Name), check.Expression)
+	}
+	return fmt.Sprintf("CHECK (%s)", check.Expression)
+}
+
+// quoteName quotes an identifier, each part of a schema qualified one
+func (d *ddlDialect) quoteName(name string) string {
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		parts[i] = fmt.Sprintf(d.quote, part)
+	}
+	return strings.Join(parts, ".")
+}
+
+func (d *ddlDialect) quoteList(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = d.quoteName(name)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func isIntegerType(goType string) bool {
+	switch strings.TrimPrefix(goType, "*") {
+	case "int", "int32", "int64", "uint", "uint32", "uint64":
+		return true
+	}
+	return false
+}
+
+// unqualifiedTable returns a table name without its schema
+func unqualifiedTable(table string) string {
+	return table[strings.LastIndex(table, ".")+1:]
+}
@@ -0,0 +1,347 @@
+package plugin
+
+import (
+	"strings"
+
+	gorm "github.com/TheSDTM/protoc-gen-gorm/options"
+	"github.com/gogo/protobuf/proto"
+	pgs "github.com/lyft/protoc-gen-star"
+)
+
+// DB Engine Enum
+const (
+	ENGINE_UNSET = iota
+	ENGINE_POSTGRES
+	ENGINE_MYSQL
+	ENGINE_SQLITE
+	ENGINE_SQLSERVER
+	ENGINE_COCKROACH
+)
+
+// engines maps the values of the engine parameter to the DB engines
+var engines = map[string]int{
+	"postgres":  ENGINE_POSTGRES,
+	"mysql":     ENGINE_MYSQL,
+	"sqlite":    ENGINE_SQLITE,
+	"sqlserver": ENGINE_SQLSERVER,
+	"cockroach": ENGINE_COCKROACH,
+}
+
+// typeMapping is the ORM representation of a proto type on a DB engine
+type typeMapping struct {
+	// goType is the type of the ORM field, declared in pkg if it is set
+	goType string
+	pkg    string
+	// column is the DB column type, the choice is left to GORM when empty
+	column string
+}
+
+// jsonMapping stores gorm.types.JSONValue fields
+type jsonMapping struct {
+	typeMapping
+	// toORM converts the document string %[2]s to the goType %[1]s
+	toORM string
+	// toPB converts the goType pointer %s to the document string
+	toPB string
+}
+
+// dialect describes how a DB engine stores the proto types which have no
+// obvious column type
+type dialect struct {
+	uuidColumn string
+	// mixedEndianUUID is set when the engine returns the first three groups
+	// of a UUID column little-endian, the generated code reorders the bytes
+	// of the UUIDs it reads then
+	mixedEndianUUID bool
+	inetColumn      string
+	timeOnlyColumn  string
+	timestampColumn string
+	binaryColumn    string
+	// uuidKeyDefault and integerKeyDefault are the column defaults of UUID
+	// and 64 bit integer primary keys. Integer keys with a default do not
+	// auto increment, 32 bit keys must not auto increment then.
+	uuidKeyDefault    string
+	integerKeyDefault string
+	// json is nil when the engine cannot store JSONValue fields, they are
+	// dropped then
+	json *jsonMapping
+	// structType stores google.protobuf.Struct fields as a JSON document, the
+	// Go type must be assignable from and to []byte
+	structType typeMapping
+	// document stores the JSON documents encoded by the generated code, the
+	// Go type must be assignable from and to []byte
+	document typeMapping
+	// stringMap stores map<string, string> fields if set, the Go type must be
+	// a map[string]*string. Other maps are stored as documents.
+	stringMap *typeMapping
+	// arrays maps the Go types of repeated scalar fields to their ORM types,
+	// the ORM type must be a slice with the same element type. Repeated enums
+	// use the array of their ORM type.
+	arrays map[string]typeMapping
+	// nativeEnumColumn is the column type of natively stored enums given the
+	// type name %[1]s and the quoted value names %[2]s, "" if the engine has
+	// no enum types. nativeEnumDDL creates the type if the engine needs it.
+	nativeEnumColumn string
+	nativeEnumDDL    string
+	// nativeEnumArrayColumn is the array column type of repeated natively
+	// stored enums given the type name %[1]s, "" if the engine has none
+	nativeEnumArrayColumn string
+}
+
+var dialects = map[int]*dialect{
+	ENGINE_UNSET: {
+		inetColumn:     "varchar(48)",
+		timeOnlyColumn: "time",
+		structType:     typeMapping{goType: "[]byte"},
+		document:       gtypesType("JSON", "text"),
+		arrays:         jsonArrays("text"),
+	},
+	ENGINE_POSTGRES: {
+		uuidColumn:     "uuid",
+		inetColumn:     "inet",
+		timeOnlyColumn: "time",
+		binaryColumn:   "bytea",
+		json: &jsonMapping{
+			typeMapping: typeMapping{goType: "Jsonb", pkg: gormpqImport, column: "jsonb"},
+			toORM:       "%s{RawMessage: []byte(%s)}",
+			toPB:        "string(%s.RawMessage)",
+		},
+		structType: typeMapping{goType: "[]byte"},
+		document:   gtypesType("JSON", "jsonb"),
+		stringMap:  &typeMapping{goType: "Hstore", pkg: gormpqImport, column: "hstore"},
+		arrays: map[string]typeMapping{
+			"[]bool":    pqType("BoolArray", "bool[]"),
+			"[]float32": gtypesType("Float32Array", "real[]"),
+			"[]float64": pqType("Float64Array", "float[]"),
+			"[]int32":   gtypesType("Int32Array", "integer[]"),
+			"[]int64":   pqType("Int64Array", "bigint[]"),
+			"[]uint32":  gtypesType("Uint32Array", "bigint[]"),
+			"[]uint64":  gtypesType("Uint64Array", "numeric[]"),
+			"[]string":  pqType("StringArray", "text[]"),
+			"[][]byte":  pqType("ByteaArray", "bytea[]"),
+		},
+		nativeEnumColumn:      "%[1]s",
+		nativeEnumDDL:         "DO $$ BEGIN CREATE TYPE %[1]s AS ENUM (%[2]s); EXCEPTION WHEN duplicate_object THEN NULL; END $$",
+		nativeEnumArrayColumn: "%[1]s[]",
+	},
+	ENGINE_MYSQL: {
+		uuidColumn:     "char(36)",
+		inetColumn:     "varchar(48)",
+		timeOnlyColumn: "time",
+		binaryColumn:   "longblob",
+		json: &jsonMapping{
+			typeMapping: gtypesType("JSON", "json"),
+			toORM:       "%s(%s)",
+			toPB:        "string(*%s)",
+		},
+		structType:       gtypesType("JSON", "json"),
+		document:         gtypesType("JSON", "json"),
+		arrays:           jsonArrays("json"),
+		nativeEnumColumn: "enum(%[2]s)",
+	},
+	ENGINE_SQLITE: {
+		uuidColumn:     "text",
+		inetColumn:     "text",
+		timeOnlyColumn: "text",
+		binaryColumn:   "blob",
+		json: &jsonMapping{
+			typeMapping: gtypesType("JSON", "text"),
+			toORM:       "%s(%s)",
+			toPB:        "string(*%s)",
+		},
+		structType: gtypesType("JSON", "text"),
+		document:   gtypesType("JSON", "text"),
+		arrays:     jsonArrays("text"),
+	},
+	ENGINE_SQLSERVER: {
+		uuidColumn:      "uniqueidentifier",
+		mixedEndianUUID: true,
+		inetColumn:      "varchar(48)",
+		timeOnlyColumn:  "time",
+		timestampColumn: "datetimeoffset",
+		binaryColumn:    "varbinary(max)",
+		json: &jsonMapping{
+			typeMapping: gtypesType("JSON", "nvarchar(max)"),
+			toORM:       "%s(%s)",
+			toPB:        "string(*%s)",
+		},
+		structType: gtypesType("JSON", "nvarchar(max)"),
+		document:   gtypesType("JSON", "nvarchar(max)"),
+		arrays:     jsonArrays("nvarchar(max)"),
+	},
+	ENGINE_COCKROACH: {
+		uuidColumn:        "UUID",
+		inetColumn:        "INET",
+		timeOnlyColumn:    "TIME",
+		binaryColumn:      "BYTES",
+		uuidKeyDefault:    "gen_random_uuid()",
+		integerKeyDefault: "unique_rowid()",
+		json: &jsonMapping{
+			typeMapping: typeMapping{goType: "Jsonb", pkg: gormpqImport, column: "JSONB"},
+			toORM:       "%s{RawMessage: []byte(%s)}",
+			toPB:        "string(%s.RawMessage)",
+		},
+		structType: typeMapping{goType: "[]byte"},
+		document:   gtypesType("JSON", "JSONB"),
+		arrays: map[string]typeMapping{
+			"[]bool":    pqType("BoolArray", "BOOL[]"),
+			"[]float32": gtypesType("Float32Array", "FLOAT4[]"),
+			"[]float64": pqType("Float64Array", "FLOAT8[]"),
+			"[]int32":   gtypesType("Int32Array", "INT4[]"),
+			"[]int64":   pqType("Int64Array", "INT8[]"),
+			"[]uint32":  gtypesType("Uint32Array", "INT8[]"),
+			"[]uint64":  gtypesType("Uint64Array", "DECIMAL[]"),
+			"[]string":  pqType("StringArray", "STRING[]"),
+			"[][]byte":  pqType("ByteaArray", "BYTES[]"),
+		},
+		nativeEnumColumn:      "%[1]s",
+		nativeEnumDDL:         "CREATE TYPE IF NOT EXISTS %[1]s AS ENUM (%[2]s)",
+		nativeEnumArrayColumn: "%[1]s[]",
+	},
+}
+
+// jsonArrays stores every repeated scalar type as a JSON document in column
+func jsonArrays(column string) map[string]typeMapping {
+	return map[string]typeMapping{
+		"[]bool":    gtypesType("JSONBoolArray", column),
+		"[]float32": gtypesType("JSONFloat32Array", column),
+		"[]float64": gtypesType("JSONFloat64Array", column),
+		"[]int32":   gtypesType("JSONInt32Array", column),
+		"[]int64":   gtypesType("JSONInt64Array", column),
+		"[]uint32":  gtypesType("JSONUint32Array", column),
+		"[]uint64":  gtypesType("JSONUint64Array", column),
+		"[]string":  gtypesType("JSONStringArray", column),
+		"[][]byte":  gtypesType("JSONBytesArray", column),
+	}
+}
+
+func gtypesType(name string, column string) typeMapping {
+	return typeMapping{goType: name, pkg: gtypesImport, column: column}
+}
+
+func pqType(name string, column string) typeMapping {
+	return typeMapping{goType: name, pkg: pqImport, column: column}
+}
+
+// parseEngine returns the DB engine named by the engine parameter
+func (p *OrmPlugin) parseEngine(name string) int {
+	if name == "" {
+		return ENGINE_UNSET
+	}
+	engine, ok := engines[strings.ToLower(name)]
+	if !ok {
+		p.Failf("unknown engine %q", name)
+	}
+	return engine
+}
+
+// engineName returns the name of a DB engine in the engine parameter
+func engineName(engine int) string {
+	for name, e := range engines {
+		if e == engine {
+			return name
+		}
+	}
+	return "unset"
+}
+
+// mappedType returns the Go type of a mapping, qualified with the alias of its
+// package in the current file
+func (p *OrmPlugin) mappedType(m typeMapping) string {
+	if m.pkg == "" {
+		return m.goType
+	}
+	name := strings.TrimLeft(m.goType, "*")
+	return m.goType[:len(m.goType)-len(name)] + p.qualifier(m.pkg) + "." + name
+}
+
+// useMapping sets the column type of a mapped type in the field options and
+// returns the Go type of the field
+func (p *OrmPlugin) useMapping(m typeMapping, fieldOpts *gorm.GormFieldOptions) string {
+	if m.column != "" {
+		fieldOpts.Tag = tagWithType(fieldOpts.GetTag(), m.column)
+	}
+	return p.mappedType(m)
+}
+
+// useColumn sets the column type in the field options unless it is empty
+func useColumn(column string, fieldOpts *gorm.GormFieldOptions) {
+	if column != "" {
+		fieldOpts.Tag = tagWithType(fieldOpts.GetTag(), column)
+	}
+}
+
+// setKeyDefault gives the primary key of an ormable type the column default
+// of the engine unless the key already has a default. The columns of a
+// composite key are set by the application.
+func (p *OrmPlugin) setKeyDefault(ormable *OrmableType) {
+	keys := p.primaryKeys(ormable)
+	if len(keys) != 1 {
+		return
+	}
+	pk := ormable.Fields[keys[0]]
+	if pk.GormFieldOptions == nil || pk.GetTag() != nil && pk.GetTag().Default != nil {
+		return
+	}
+	switch pkType := strings.TrimPrefix(pk.Type, "*"); {
+	case pk.Package == uuidImport:
+		if p.dialect.uuidKeyDefault != "" {
+			pk.Tag = tagWithDefault(pk.GetTag(), p.dialect.uuidKeyDefault)
+		}
+	case pkType == "int64", pkType == "uint64":
+		if p.dialect.integerKeyDefault != "" {
+			pk.Tag = tagWithDefault(pk.GetTag(), p.dialect.integerKeyDefault)
+			if pk.Tag.AutoIncrement == nil {
+				pk.Tag.AutoIncrement = proto.Bool(false)
+			}
+		}
+	case pkType == "int32", pkType == "uint32":
+		// the key default of the engine needs 64 bits, a 32 bit key would
+		// have no default at all
+		if p.dialect.integerKeyDefault != "" && (pk.GetTag() == nil || pk.GetTag().AutoIncrement == nil || *pk.GetTag().AutoIncrement) {
+			p.Failf("the 32 bit primary key %s of %s cannot be generated by %s, whose %s needs 64 bits: make it an int64, give it a default or disable its auto increment",
+				keys[0], ormable.Name, engineName(p.dbEngine), p.dialect.integerKeyDefault)
+		}
+	}
+}
+
+func tagWithDefault(tag *gorm.GormTag, value string) *gorm.GormTag {
+	if tag == nil {
+		tag = &gorm.GormTag{}
+	}
+	tag.Default = proto.String(value)
+	return tag
+}
+
+// generateUUIDReorder gives the ORM type of a message an AfterFind hook which
+// reorders the UUIDs read from an engine storing them mixed-endian. UUIDs are
+// written as text, so their bytes are only reordered after a query.
+func (p *OrmPlugin) generateUUIDReorder(message pgs.Message) {
+	if !p.dialect.mixedEndianUUID {
+		return
+	}
+	ormable := p.getOrmable(message)
+	var names []string
+	for _, name := range ormable.FieldsOrder {
+		if field := ormable.Fields[name]; field.Package == uuidImport && !strings.HasPrefix(field.Type, "[]") {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return
+	}
+	p.P(`// AfterFind reorders the bytes of the UUIDs read from mixed-endian uniqueidentifier columns`)
+	p.P(`func (m *`, ormable.Name, `) AfterFind(*`, p.Import(gormImport), `.DB) error {`)
+	for _, name := range names {
+		if strings.HasPrefix(ormable.Fields[name].Type, "*") {
+			p.P(`if m.`, name, ` != nil {`)
+			p.P(p.Import(gtypesImport), `.ReorderUniqueIdentifier((*[16]byte)(m.`, name, `))`)
+			p.P(`}`)
+		} else {
+			p.P(p.Import(gtypesImport), `.ReorderUniqueIdentifier((*[16]byte)(&m.`, name, `))`)
+		}
+	}
+	p.P(`return nil`)
+	p.P(`}`)
+	p.P()
+}
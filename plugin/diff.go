@@ -0,0 +1,480 @@
+package plugin
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+const diffUsage = "usage: protoc-gen-gorm diff [-up file] [-down file] old.schema.json new.schema.json"
+
+// RunDiff runs the diff command: it writes the up migration from the old
+// schema snapshot to the new one and the down migration back to the -up and
+// -down files, or both to out. A missing old snapshot is an empty schema.
+func RunDiff(args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("diff", flag.ContinueOnError)
+	upFile := flags.String("up", "", "file of the up migration")
+	downFile := flags.String("down", "", "file of the down migration")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 2 {
+		return fmt.Errorf(diffUsage)
+	}
+	from, err := readSchema(flags.Arg(0), true)
+	if err != nil {
+		return err
+	}
+	to, err := readSchema(flags.Arg(1), false)
+	if err != nil {
+		return err
+	}
+	up, down, err := Migrations(from, to)
+	if err != nil {
+		return err
+	}
+	for _, migration := range []struct {
+		file       string
+		title      string
+		statements []string
+	}{
+		{*upFile, "Up", up},
+		{*downFile, "Down", down},
+	} {
+		script := fmt.Sprintf("-- %s migration generated by protoc-gen-gorm from %s to %s\n%s",
+			migration.title, flags.Arg(0), flags.Arg(1), sqlScript(migration.statements))
+		if migration.file == "" {
+			fmt.Fprintln(out, script)
+			continue
+		}
+		if err := ioutil.WriteFile(migration.file, []byte(script), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readSchema(name string, optional bool) (*Schema, error) {
+	content, err := ioutil.ReadFile(name)
+	if optional && (os.IsNotExist(err) || err == nil && len(strings.TrimSpace(string(content))) == 0) {
+		return &Schema{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	schema := &Schema{}
+	if err := json.Unmarshal(content, schema); err != nil {
+		return nil, fmt.Errorf("cannot decode the schema snapshot %s: %s", name, err)
+	}
+	return schema, nil
+}
+
+// sqlScript terminates the statements, the comments are left as they are
+func sqlScript(statements []string) string {
+	var b strings.Builder
+	for _, statement := range statements {
+		b.WriteString(statement)
+		if !strings.HasPrefix(statement, "--") {
+			b.WriteString(";")
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// Migrations returns the statements migrating a database from a schema
+// snapshot to another one and the statements migrating it back. The changes
+// the engine cannot make in place are comments asking for a manual migration.
+func Migrations(from *Schema, to *Schema) (up []string, down []string, err error) {
+	if from.Engine != "" && from.Engine != to.Engine {
+		return nil, nil, fmt.Errorf("cannot migrate from the %s engine to the %s engine", from.Engine, to.Engine)
+	}
+	// a file missing from the new snapshot was left out of the protoc
+	// invocation, its tables must not be dropped
+	if missing := missingFiles(from, to); len(missing) > 0 {
+		return nil, nil, fmt.Errorf("the new snapshot lacks the files %s of the old one, generate it with every file of the package or remove the deleted files from the old one", strings.Join(missing, ", "))
+	}
+	d, ok := ddlDialects[engines[to.Engine]]
+	if !ok {
+		return nil, nil, fmt.Errorf("no migration can be generated for the %q engine", to.Engine)
+	}
+	// the renamed_from options of the new snapshot rename the columns of the
+	// old one, unless the old table already has the new column
+	upRenames, downRenames := map[string]map[string]string{}, map[string]map[string]string{}
+	old := tablesByName(from)
+	for _, table := range to.Tables {
+		for _, column := range table.Columns {
+			previous := old[table.Name]
+			if column.RenamedFrom == "" || previous == nil || previous.column(column.RenamedFrom) == nil || previous.column(column.Name) != nil {
+				continue
+			}
+			if upRenames[table.Name] == nil {
+				upRenames[table.Name], downRenames[table.Name] = map[string]string{}, map[string]string{}
+			}
+			upRenames[table.Name][column.Name] = column.RenamedFrom
+			downRenames[table.Name][column.RenamedFrom] = column.Name
+		}
+	}
+	up = (&migration{d: d, engine: to.Engine, from: from, to: to, renames: upRenames}).statements()
+	down = (&migration{d: d, engine: to.Engine, from: to, to: from, renames: downRenames}).statements()
+	return up, down, nil
+}
+
+// missingFiles returns the files of the old snapshot which the new one lacks
+func missingFiles(from *Schema, to *Schema) []string {
+	files := map[string]bool{}
+	for _, file := range to.Files {
+		files[file] = true
+	}
+	var missing []string
+	for _, file := range from.Files {
+		if !files[file] {
+			missing = append(missing, file)
+		}
+	}
+	return missing
+}
+
+// migration computes the statements migrating a database from a schema to
+// another one, renames maps the columns of the tables of the target schema
+// to the columns of the source schema they are renamed from
+type migration struct {
+	d        *ddlDialect
+	engine   string
+	from     *Schema
+	to       *Schema
+	renames  map[string]map[string]string
+	out      []string
+	old, new map[string]*SchemaTable
+}
+
+func (m *migration) add(format string, args ...interface{}) {
+	m.out = append(m.out, fmt.Sprintf(format, args...))
+}
+
+func (m *migration) manual(format string, args ...interface{}) {
+	m.out = append(m.out, "-- manual migration needed: "+fmt.Sprintf(format, args...))
+}
+
+// source returns the column of the source table a column of the target table
+// is migrated from
+func (m *migration) source(table string, column string) string {
+	if previous, ok := m.renames[table][column]; ok {
+		return previous
+	}
+	return column
+}
+
+// statements returns the statements in an order keeping the schema valid:
+// the constraints and indexes which change are dropped first, the tables and
+// columns are then created, renamed, altered and dropped and the new indexes
+// and constraints are finally created on the migrated columns
+func (m *migration) statements() []string {
+	m.old, m.new = tablesByName(m.from), tablesByName(m.to)
+	m.dropForeignKeys()
+	m.dropIndexes()
+	m.createEnums()
+	for _, table := range m.to.Tables {
+		if m.old[table.Name] == nil {
+			m.add("%s", m.d.createTable(table))
+		}
+	}
+	for _, table := range m.to.Tables {
+		if previous := m.old[table.Name]; previous != nil {
+			m.migrateColumns(previous, table)
+		}
+	}
+	for i := len(m.from.Tables) - 1; i >= 0; i-- {
+		if table := m.from.Tables[i]; m.new[table.Name] == nil {
+			m.add("DROP TABLE %s", m.d.quoteName(table.Name))
+		}
+	}
+	m.createIndexes()
+	m.addForeignKeys()
+	for _, enum := range m.from.Enums {
+		if findEnum(m.to, enum.Name) == nil {
+			m.add("DROP TYPE %s", enum.Name)
+		}
+	}
+	return m.out
+}
+
+func (m *migration) dropForeignKeys() {
+	for _, table := range m.from.Tables {
+		target := m.new[table.Name]
+		for _, fk := range table.ForeignKeys {
+			if target != nil && m.sameForeignKey(target, fk, findForeignKey(target, fk.Name)) {
+				continue
+			}
+			switch {
+			case m.d.dropForeignKey != "":
+				m.add(m.d.dropForeignKey, m.d.quoteName(table.Name), m.d.quoteName(fk.Name))
+			case target != nil:
+				m.manual("drop the foreign key %s of %s, the engine cannot alter constraints", fk.Name, table.Name)
+			}
+		}
+	}
+}
+
+func (m *migration) dropIndexes() {
+	for _, table := range m.from.Tables {
+		target := m.new[table.Name]
+		if target == nil {
+			continue
+		}
+		for _, index := range table.Indexes {
+			if m.sameIndex(target, index, findIndex(target, index.Name)) {
+				continue
+			}
+			// the index lives in the schema of its table
+			qualified := table.Name[:strings.LastIndex(table.Name, ".")+1] + index.Name
+			m.add(m.d.dropIndex, m.d.quoteName(qualified), m.d.quoteName(table.Name), m.d.quoteName(index.Name))
+		}
+	}
+}
+
+func (m *migration) createEnums() {
+	for _, enum := range m.to.Enums {
+		previous := findEnum(m.from, enum.Name)
+		if previous == nil {
+			m.add("%s", createEnum(m.engine, enum))
+			continue
+		}
+		for _, value := range enum.Values {
+			switch {
+			case containsString(previous.Values, value):
+			case m.d.addEnumValue != "":
+				m.add(m.d.addEnumValue, enum.Name, sqlStrings([]string{value}))
+			default:
+				m.manual("add the value %s to the enum type %s", value, enum.Name)
+			}
+		}
+		for _, value := range previous.Values {
+			if !containsString(enum.Values, value) {
+				m.manual("remove the value %s from the enum type %s", value, enum.Name)
+			}
+		}
+	}
+}
+
+// migrateColumns renames, adds, alters and drops the columns of a table
+func (m *migration) migrateColumns(previous *SchemaTable, table *SchemaTable) {
+	d := m.d
+	name := d.quoteName(table.Name)
+	for _, column := range table.Columns {
+		if source := m.source(table.Name, column.Name); source != column.Name {
+			m.add(d.renameColumn, name, d.quoteName(source), d.quoteName(column.Name), table.Name, source, column.Name)
+		}
+	}
+	for _, column := range table.Columns {
+		if previous.column(m.source(table.Name, column.Name)) == nil {
+			m.add(d.addColumn, name, d.columnDefinition(column))
+		}
+	}
+	for _, column := range table.Columns {
+		if old := previous.column(m.source(table.Name, column.Name)); old != nil {
+			m.alterColumn(table, old, column)
+		}
+	}
+	if !sameStrings(m.sourceColumns(table.Name, table.PrimaryKey), previous.PrimaryKey) {
+		m.manual("change the primary key of %s to (%s)", table.Name, strings.Join(table.PrimaryKey, ", "))
+	}
+	for _, check := range table.Checks {
+		if !containsCheck(previous.Checks, check) {
+			m.manual("add the check %s to %s", check.Expression, table.Name)
+		}
+	}
+	for _, check := range previous.Checks {
+		if !containsCheck(table.Checks, check) {
+			m.manual("remove the check %s from %s", check.Expression, table.Name)
+		}
+	}
+	renamed := map[string]bool{}
+	for _, source := range m.renames[table.Name] {
+		renamed[source] = true
+	}
+	for _, column := range previous.Columns {
+		if table.column(column.Name) == nil && !renamed[column.Name] {
+			m.add("ALTER TABLE %s DROP COLUMN %s", name, d.quoteName(column.Name))
+		}
+	}
+}
+
+// alterColumn changes the type, the nullability and the default of a column
+func (m *migration) alterColumn(table *SchemaTable, old *SchemaColumn, column *SchemaColumn) {
+	d, name, quoted := m.d, m.d.quoteName(table.Name), m.d.quoteName(column.Name)
+	typeChanged := old.Type != column.Type
+	nullChanged := old.NotNull != column.NotNull
+	defaultChanged := (old.Default == nil) != (column.Default == nil) || old.Default != nil && *old.Default != *column.Default
+	if old.Unique != column.Unique || old.AutoIncrement != column.AutoIncrement {
+		m.manual("change the uniqueness or the auto increment of %s.%s", table.Name, column.Name)
+	}
+	if !typeChanged && !nullChanged && !defaultChanged {
+		return
+	}
+	if d.modifyColumn != "" {
+		// the unique constraint is kept by the redefinition
+		definition := *column
+		definition.Unique = false
+		m.add(d.modifyColumn, name, d.columnDefinition(&definition))
+		return
+	}
+	if typeChanged || nullChanged && d.alterNullability {
+		columnType := column.Type
+		if d.alterNullability && column.NotNull {
+			columnType += " NOT NULL"
+		} else if d.alterNullability {
+			columnType += " NULL"
+		}
+		if d.alterType != "" {
+			m.add(d.alterType, name, quoted, columnType)
+		} else {
+			m.manual("change the type of %s.%s to %s", table.Name, column.Name, columnType)
+		}
+	}
+	if nullChanged && !d.alterNullability {
+		statement := d.dropNotNull
+		if column.NotNull {
+			statement = d.setNotNull
+		}
+		if statement != "" {
+			m.add(statement, name, quoted)
+		} else {
+			m.manual("change the nullability of %s.%s", table.Name, column.Name)
+		}
+	}
+	if defaultChanged {
+		switch {
+		case column.Default != nil && d.setDefault != "":
+			m.add(d.setDefault, name, quoted, *column.Default)
+		case column.Default == nil && d.dropDefault != "":
+			m.add(d.dropDefault, name, quoted)
+		default:
+			m.manual("change the default of %s.%s", table.Name, column.Name)
+		}
+	}
+}
+
+func (m *migration) createIndexes() {
+	for _, table := range m.to.Tables {
+		previous := m.old[table.Name]
+		for _, index := range table.Indexes {
+			if previous == nil || !m.sameIndex(table, findIndex(previous, index.Name), index) {
+				m.add("%s", m.d.createIndex(table, index))
+			}
+		}
+	}
+}
+
+func (m *migration) addForeignKeys() {
+	for _, table := range m.to.Tables {
+		previous := m.old[table.Name]
+		if previous == nil && m.d.inlineForeignKeys {
+			continue
+		}
+		for _, fk := range table.ForeignKeys {
+			if previous != nil && m.sameForeignKey(table, findForeignKey(previous, fk.Name), fk) {
+				continue
+			}
+			if m.d.inlineForeignKeys {
+				m.manual("add the foreign key %s to %s, the engine cannot alter constraints", fk.Name, table.Name)
+			} else {
+				m.add("%s", m.d.addForeignKey(table, fk))
+			}
+		}
+	}
+}
+
+// sameIndex tells whether an index of the source schema is migrated to an
+// index of the target table as it is
+func (m *migration) sameIndex(table *SchemaTable, old *SchemaIndex, index *SchemaIndex) bool {
+	return old != nil && index != nil && old.Unique == index.Unique && old.Class == index.Class &&
+		old.Type == index.Type && old.Where == index.Where && sameStrings(old.Sorts, index.Sorts) &&
+		sameStrings(old.Columns, m.sourceColumns(table.Name, index.Columns)) &&
+		sameStrings(old.Include, m.sourceColumns(table.Name, index.Include))
+}
+
+// sameForeignKey tells whether a foreign key of the source schema is migrated
+// to a foreign key of the target table as it is
+func (m *migration) sameForeignKey(table *SchemaTable, old *SchemaForeignKey, fk *SchemaForeignKey) bool {
+	return old != nil && fk != nil && sameStrings(old.Columns, m.sourceColumns(table.Name, fk.Columns)) &&
+		old.RefTable == fk.RefTable && sameStrings(old.RefColumns, m.sourceColumns(fk.RefTable, fk.RefColumns)) &&
+		old.OnUpdate == fk.OnUpdate && old.OnDelete == fk.OnDelete
+}
+
+func (m *migration) sourceColumns(table string, columns []string) []string {
+	sources := make([]string, len(columns))
+	for i, column := range columns {
+		sources[i] = m.source(table, column)
+	}
+	return sources
+}
+
+func tablesByName(schema *Schema) map[string]*SchemaTable {
+	tables := map[string]*SchemaTable{}
+	for _, table := range schema.Tables {
+		tables[table.Name] = table
+	}
+	return tables
+}
+
+func findEnum(schema *Schema, name string) *SchemaEnum {
+	for _, enum := range schema.Enums {
+		if enum.Name == name {
+			return enum
+		}
+	}
+	return nil
+}
+
+func findIndex(table *SchemaTable, name string) *SchemaIndex {
+	for _, index := range table.Indexes {
+		if index.Name == name {
+			return index
+		}
+	}
+	return nil
+}
+
+func findForeignKey(table *SchemaTable, name string) *SchemaForeignKey {
+	for _, fk := range table.ForeignKeys {
+		if fk.Name == name {
+			return fk
+		}
+	}
+	return nil
+}
+
+func containsCheck(checks []*SchemaCheck, check *SchemaCheck) bool {
+	for _, c := range checks {
+		if *c == *check {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func sameStrings(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
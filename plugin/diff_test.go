@@ -0,0 +1,141 @@
+package plugin
+
+import (
+	"reflect"
+	"testing"
+)
+
+func usersTable(columns ...*SchemaColumn) *SchemaTable {
+	return &SchemaTable{
+		Name:       "users",
+		Columns:    append([]*SchemaColumn{{Name: "id", Type: "bigint", AutoIncrement: true, NotNull: true}}, columns...),
+		PrimaryKey: []string{"id"},
+	}
+}
+
+func postsTable(foreignKeys ...*SchemaForeignKey) *SchemaTable {
+	return &SchemaTable{
+		Name: "posts",
+		Columns: []*SchemaColumn{
+			{Name: "id", Type: "bigint", AutoIncrement: true, NotNull: true},
+			{Name: "user_id", Type: "bigint"},
+		},
+		PrimaryKey:  []string{"id"},
+		ForeignKeys: foreignKeys,
+	}
+}
+
+var postsUserKey = &SchemaForeignKey{Name: "fk_posts_user", Columns: []string{"user_id"}, RefTable: "users", RefColumns: []string{"id"}, OnDelete: "CASCADE"}
+
+func TestMigrations(t *testing.T) {
+	defaultAge := "0"
+	cases := []struct {
+		name string
+		from *Schema
+		to   *Schema
+		up   []string
+		down []string
+	}{
+		{
+			name: "renamed column",
+			from: &Schema{Engine: "postgres", Tables: []*SchemaTable{usersTable(&SchemaColumn{Name: "last", Type: "text"})}},
+			to:   &Schema{Engine: "postgres", Tables: []*SchemaTable{usersTable(&SchemaColumn{Name: "surname", Type: "text", RenamedFrom: "last"})}},
+			up:   []string{`ALTER TABLE "users" RENAME COLUMN "last" TO "surname"`},
+			down: []string{`ALTER TABLE "users" RENAME COLUMN "surname" TO "last"`},
+		},
+		{
+			name: "not null column with default",
+			from: &Schema{Engine: "postgres", Tables: []*SchemaTable{usersTable()}},
+			to:   &Schema{Engine: "postgres", Tables: []*SchemaTable{usersTable(&SchemaColumn{Name: "age", Type: "integer", NotNull: true, Default: &defaultAge})}},
+			up:   []string{`ALTER TABLE "users" ADD COLUMN "age" integer NOT NULL DEFAULT 0`},
+			down: []string{`ALTER TABLE "users" DROP COLUMN "age"`},
+		},
+		{
+			name: "changed index",
+			from: &Schema{Engine: "postgres", Tables: []*SchemaTable{func() *SchemaTable {
+				table := usersTable(&SchemaColumn{Name: "name", Type: "text"}, &SchemaColumn{Name: "email", Type: "text"})
+				table.Indexes = []*SchemaIndex{{Name: "idx_users_name", Columns: []string{"name"}}}
+				return table
+			}()}},
+			to: &Schema{Engine: "postgres", Tables: []*SchemaTable{func() *SchemaTable {
+				table := usersTable(&SchemaColumn{Name: "name", Type: "text"}, &SchemaColumn{Name: "email", Type: "text"})
+				table.Indexes = []*SchemaIndex{{Name: "idx_users_name", Unique: true, Columns: []string{"name", "email"}}}
+				return table
+			}()}},
+			up: []string{
+				`DROP INDEX "idx_users_name"`,
+				`CREATE UNIQUE INDEX "idx_users_name" ON "users" ("name", "email")`,
+			},
+			down: []string{
+				`DROP INDEX "idx_users_name"`,
+				`CREATE INDEX "idx_users_name" ON "users" ("name")`,
+			},
+		},
+		{
+			name: "dropped table with incoming foreign keys",
+			from: &Schema{Engine: "postgres", Tables: []*SchemaTable{usersTable(), postsTable(postsUserKey)}},
+			to:   &Schema{Engine: "postgres", Tables: []*SchemaTable{postsTable()}},
+			up: []string{
+				`ALTER TABLE "posts" DROP CONSTRAINT "fk_posts_user"`,
+				`DROP TABLE "users"`,
+			},
+			down: []string{
+				`CREATE TABLE "users" (
+  "id" bigint GENERATED BY DEFAULT AS IDENTITY NOT NULL,
+  PRIMARY KEY ("id")
+)`,
+				`ALTER TABLE "posts" ADD CONSTRAINT "fk_posts_user" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE`,
+			},
+		},
+		{
+			name: "manual sqlite migrations",
+			from: &Schema{Engine: "sqlite", Tables: []*SchemaTable{usersTable(&SchemaColumn{Name: "age", Type: "integer"}), postsTable()}},
+			to:   &Schema{Engine: "sqlite", Tables: []*SchemaTable{usersTable(&SchemaColumn{Name: "age", Type: "text", NotNull: true}), postsTable(postsUserKey)}},
+			up: []string{
+				"-- manual migration needed: change the type of users.age to text",
+				"-- manual migration needed: change the nullability of users.age",
+				"-- manual migration needed: add the foreign key fk_posts_user to posts, the engine cannot alter constraints",
+			},
+			down: []string{
+				"-- manual migration needed: drop the foreign key fk_posts_user of posts, the engine cannot alter constraints",
+				"-- manual migration needed: change the type of users.age to integer",
+				"-- manual migration needed: change the nullability of users.age",
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			up, down, err := Migrations(c.from, c.to)
+			if err != nil {
+				t.Fatalf("Got unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(up, c.up) {
+				t.Errorf("Expected up migration %q, got %q", c.up, up)
+			}
+			if !reflect.DeepEqual(down, c.down) {
+				t.Errorf("Expected down migration %q, got %q", c.down, down)
+			}
+		})
+	}
+}
+
+func TestMigrationsErrors(t *testing.T) {
+	cases := []struct {
+		name string
+		from *Schema
+		to   *Schema
+	}{
+		{"other engine", &Schema{Engine: "mysql"}, &Schema{Engine: "postgres"}},
+		{"unknown engine", &Schema{}, &Schema{Engine: "oracle"}},
+		{"missing file", &Schema{Engine: "postgres", Files: []string{"a.proto", "b.proto"}}, &Schema{Engine: "postgres", Files: []string{"a.proto"}}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if _, _, err := Migrations(c.from, c.to); err == nil {
+				t.Error("Expected error but didn't get any")
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package plugin
+
+import (
+	"fmt"
+	"strings"
+
+	gorm "github.com/TheSDTM/protoc-gen-gorm/options"
+	pgs "github.com/lyft/protoc-gen-star"
+)
+
+// enumFormat is how the values of an enum field are stored
+type enumFormat struct {
+	storage gorm.EnumStorage
+	// prefix is trimmed from the value names, "" to store them as they are
+	prefix string
+	// column is the native enum column type, "" if the engine has none and
+	// the values are stored as strings instead
+	column string
+	// arrayColumn is the column type of a repeated field of native enums, ""
+	// if the engine has no such arrays and the names are stored instead
+	arrayColumn string
+}
+
+// ormType returns the Go type of the stored values
+func (f enumFormat) ormType() string {
+	if f.storage == gorm.EnumStorage_INT {
+		return "int32"
+	}
+	return "string"
+}
+
+// mapping returns the ORM representation of a singular field
+func (f enumFormat) mapping() typeMapping {
+	if f.storage == gorm.EnumStorage_NATIVE {
+		return typeMapping{goType: "string", column: f.column}
+	}
+	return typeMapping{goType: f.ormType()}
+}
+
+// fieldEnum returns the enum type of an enum field, a repeated enum field or a
+// map field with enum values
+func fieldEnum(field pgs.Field) pgs.Enum {
+	if field.Type().IsEnum() {
+		return field.Type().Enum()
+	}
+	return field.Type().Element().Enum()
+}
+
+// enumFormat returns how the values of an enum field are stored: the
+// enum_storage field option wins over the storage enum option, which wins
+// over the enum_storage file option and then the enums plugin parameter.
+// Map fields and the repeated fields of engines without native enum arrays
+// store native enums by name like STRING.
+func (p *OrmPlugin) enumFormat(field pgs.Field) enumFormat {
+	enum := fieldEnum(field)
+	enumOpts := getEnumOptions(enum)
+	format := enumFormat{storage: gorm.EnumStorage_INT}
+	if p.stringEnums {
+		format.storage = gorm.EnumStorage_STRING
+	}
+	if fileOpts := getFileOptions(field.File()); fileOpts != nil && fileOpts.EnumStorage != nil {
+		format.storage = fileOpts.GetEnumStorage()
+	}
+	if enumOpts != nil && enumOpts.Storage != nil {
+		format.storage = enumOpts.GetStorage()
+	}
+	if fieldOpts := getFieldOptions(field); fieldOpts != nil && fieldOpts.EnumStorage != nil {
+		format.storage = fieldOpts.GetEnumStorage()
+	}
+	if format.storage == gorm.EnumStorage_INT {
+		return format
+	}
+	format.prefix = enumPrefix(enum)
+	if format.storage == gorm.EnumStorage_NATIVE && p.dialect.nativeEnumColumn != "" {
+		format.column = fmt.Sprintf(p.dialect.nativeEnumColumn, nativeEnumName(enum), enumValueList(enum, format.prefix))
+	}
+	if format.storage == gorm.EnumStorage_NATIVE && p.dialect.nativeEnumArrayColumn != "" {
+		format.arrayColumn = fmt.Sprintf(p.dialect.nativeEnumArrayColumn, nativeEnumName(enum))
+	}
+	return format
+}
+
+// enumPrefix returns the prefix trimmed from the stored value names of an
+// enum, "" unless its trim_prefix option is set
+func enumPrefix(enum pgs.Enum) string {
+	if getEnumOptions(enum).GetTrimPrefix() {
+		return enum.Name().ScreamingSnakeCase().String() + "_"
+	}
+	return ""
+}
+
+// nativeEnumName returns the name of the native enum type of an enum
+func nativeEnumName(enum pgs.Enum) string {
+	if name := getEnumOptions(enum).GetTypeName(); name != "" {
+		return name
+	}
+	return enum.Name().LowerSnakeCase().String()
+}
+
+// enumValueList returns the quoted stored names of the enum values
+func enumValueList(enum pgs.Enum, prefix string) string {
+	return sqlStrings(enumValues(enum, prefix))
+}
+
+// enumValues returns the stored value names of an enum
+func enumValues(enum pgs.Enum, prefix string) []string {
+	var names []string
+	for _, value := range enum.Values() {
+		name := value.Name().String()
+		if prefix != "" {
+			name = strings.ToLower(strings.TrimPrefix(name, prefix))
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// sqlStrings returns the SQL string literals of values separated by commas
+func sqlStrings(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = "'" + value + "'"
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// enumGoType returns the Go type of the enum values of a field
+func (p *OrmPlugin) enumGoType(field pgs.Field) string {
+	if field.Type().IsEnum() {
+		return p.ctx.Type(field).String()
+	}
+	return p.ctx.Type(field).Element().String()
+}
+
+// generateEnumConversion outputs the conversion of the enum value src to the
+// assignable dst. Names which are not values of the enum are an error.
+func (p *OrmPlugin) generateEnumConversion(field pgs.Field, src string, dst string, toORM bool) {
+	format := p.enumFormat(field)
+	enumType := p.enumGoType(field)
+	switch {
+	case format.storage == gorm.EnumStorage_INT && toORM:
+		p.P(dst, ` = int32(`, src, `)`)
+	case format.storage == gorm.EnumStorage_INT:
+		p.P(dst, ` = `, enumType, `(`, src, `)`)
+	case toORM:
+		p.P(`if `, dst, `, err = `, p.Import(gtypesImport), `.EnumName(`, enumType, `_name, int32(`, src, `), "`, format.prefix, `"); err != nil {`)
+		p.P(`return to, err`)
+		p.P(`}`)
+	default:
+		p.P(`if n, err := `, p.Import(gtypesImport), `.EnumValue(`, enumType, `_value, `, src, `, "`, format.prefix, `"); err != nil {`)
+		p.P(`return to, err`)
+		p.P(`} else {`)
+		p.P(dst, ` = `, enumType, `(n)`)
+		p.P(`}`)
+	}
+}
+
+// generateNativeEnumTypes outputs a Create{Enum}EnumType function for every
+// enum of the file stored natively, if the engine needs the type to be created
+func (p *OrmPlugin) generateNativeEnumTypes(file pgs.File) {
+	if p.dialect.nativeEnumDDL == "" {
+		return
+	}
+	for _, enum := range p.nativeEnums(file) {
+		enumName := p.ctx.Name(enum).String()
+		typeName := nativeEnumName(enum)
+		p.P(`// Create`, enumName, `EnumType creates the `, typeName, ` enum type storing `, enumName, ` values`)
+		p.P(`// natively if it does not exist yet`)
+		p.P(`func Create`, enumName, `EnumType(db *`, p.Import(gormImport), `.DB) error {`)
+		p.P("return db.Exec(`", p.nativeEnumDDL(enum), "`).Error")
+		p.P(`}`)
+		p.P()
+	}
+}
+
+// nativeEnums returns the enums of the file stored natively by their own
+// option or by an ormable field of the file
+func (p *OrmPlugin) nativeEnums(file pgs.File) []pgs.Enum {
+	native := map[pgs.Enum]bool{}
+	for _, enum := range file.AllEnums() {
+		native[enum] = getEnumOptions(enum).GetStorage() == gorm.EnumStorage_NATIVE
+	}
+	for _, msg := range file.AllMessages() {
+		if !p.isOrmable(msg) {
+			continue
+		}
+		for _, field := range msg.Fields() {
+			repeated := field.Type().IsRepeated() && field.Type().Element().IsEnum()
+			if (field.Type().IsEnum() || repeated) && p.enumFormat(field).storage == gorm.EnumStorage_NATIVE {
+				if _, ok := native[fieldEnum(field)]; ok {
+					native[fieldEnum(field)] = true
+				}
+			}
+		}
+	}
+	var enums []pgs.Enum
+	for _, enum := range file.AllEnums() {
+		if native[enum] {
+			enums = append(enums, enum)
+		}
+	}
+	return enums
+}
+
+// nativeEnumDDL returns the statement creating the native type of an enum
+func (p *OrmPlugin) nativeEnumDDL(enum pgs.Enum) string {
+	return fmt.Sprintf(p.dialect.nativeEnumDDL, nativeEnumName(enum), enumValueList(enum, enumPrefix(enum)))
+}
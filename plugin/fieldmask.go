@@ -0,0 +1,436 @@
+package plugin
+
+import (
+	"strings"
+
+	"github.com/gogo/protobuf/protoc-gen-gogo/generator"
+	pgs "github.com/lyft/protoc-gen-star"
+)
+
+// generatePatchHandlers outputs the DefaultApplyFieldMask, DefaultPatch,
+// DefaultPatchColumns and DefaultPatchSet functions of an ormable type. Only
+// DefaultApplyFieldMask is generated for types without a primary key, since
+// patching the DB requires the record to be looked up.
+func (p *OrmPlugin) generatePatchHandlers(message pgs.Message) {
+	typeName := p.TypeName(message)
+	ormable := p.getOrmable(message)
+
+	p.generateApplyFieldMask(message)
+	if !p.hasPrimaryKey(ormable) {
+		return
+	}
+	p.generatePatchHandler(message, ormable)
+	p.generatePatchColumns(message)
+	p.generatePatchSetHandler(typeName)
+}
+
+// singularAssociation returns the ormable type of a has-one or belongs-to
+// association, or nil if the ORM field is not one
+func (p *OrmPlugin) singularAssociation(field pgs.Field, ofield *Field) *OrmableType {
+	if ofield == nil || field.Type().IsRepeated() || !field.Type().IsEmbed() {
+		return nil
+	}
+	if ofield.GetHasOne() == nil && ofield.GetBelongsTo() == nil {
+		return nil
+	}
+	return p.fieldOrmable(field)
+}
+
+func (p *OrmPlugin) generateApplyFieldMask(message pgs.Message) {
+	typeName := p.TypeName(message)
+	ormable := p.getOrmable(message)
+
+	p.P(`// DefaultApplyFieldMask`, typeName, ` patches a pbObject with patcher according to a field mask.`)
+	p.P(`// Paths of nested has-one and belongs-to associations are applied recursively.`)
+	p.P(`func DefaultApplyFieldMask`, typeName, `(ctx context.Context, patchee *`, typeName, `, patcher *`, typeName,
+		`, updateMask *`, p.Import(fieldmaskImport), `.FieldMask, prefix string, db *`, p.Import(gormImport), `.DB) (*`, typeName, `, error) {`)
+	p.P(`if patcher == nil {`)
+	p.P(`return nil, nil`)
+	p.P(`} else if patchee == nil {`)
+	p.P(`return nil, `, p.Import(gerrorsImport), `.NilArgumentError`)
+	p.P(`}`)
+	var nested []pgs.Field
+	for _, field := range message.Fields() {
+		if getFieldOptions(field).GetDrop() {
+			continue
+		}
+		ofield := ormable.Fields[generator.CamelCase(string(field.Name()))]
+		// oneof members are always patched as a whole
+		if p.singularAssociation(field, ofield) != nil && !inRealOneOf(field) {
+			nested = append(nested, field)
+			p.P(`var updated`, generator.CamelCase(string(field.Name())), ` bool`)
+		}
+	}
+	p.P(`for _, f := range updateMask.GetPaths() {`)
+	oneofs := map[string]struct{}{}
+	for _, field := range message.Fields() {
+		if getFieldOptions(field).GetDrop() {
+			continue
+		}
+		fieldName := generator.CamelCase(string(field.Name()))
+		if inRealOneOf(field) {
+			oneofName := generator.CamelCase(string(field.OneOf().Name()))
+			if _, ok := oneofs[oneofName]; !ok {
+				oneofs[oneofName] = struct{}{}
+				p.P(`if f == prefix+"`, string(field.OneOf().Name()), `" {`)
+				p.P(`patchee.`, oneofName, ` = patcher.`, oneofName)
+				p.P(`continue`)
+				p.P(`}`)
+			}
+			option := p.ctx.OneofOption(field).String()
+			p.P(`if f == prefix+"`, string(field.Name()), `" {`)
+			p.P(`if v, ok := patcher.`, oneofName, `.(*`, option, `); ok {`)
+			p.P(`patchee.`, oneofName, ` = v`)
+			p.P(`} else if _, ok := patchee.`, oneofName, `.(*`, option, `); ok {`)
+			p.P(`patchee.`, oneofName, ` = nil`)
+			p.P(`}`)
+			p.P(`continue`)
+			p.P(`}`)
+			continue
+		}
+		p.P(`if f == prefix+"`, string(field.Name()), `" {`)
+		p.P(`patchee.`, fieldName, ` = patcher.`, fieldName)
+		p.P(`continue`)
+		p.P(`}`)
+	}
+	for _, field := range nested {
+		fieldName := generator.CamelCase(string(field.Name()))
+		assocType := p.fieldTypeName(field)
+		p.P(`if !updated`, fieldName, ` && strings.HasPrefix(f, prefix+"`, string(field.Name()), `.") {`)
+		p.P(`updated`, fieldName, ` = true`)
+		p.P(`if patcher.`, fieldName, ` == nil {`)
+		p.P(`patchee.`, fieldName, ` = nil`)
+		p.P(`continue`)
+		p.P(`}`)
+		p.P(`if patchee.`, fieldName, ` == nil {`)
+		p.P(`patchee.`, fieldName, ` = &`, assocType, `{}`)
+		p.P(`}`)
+		p.P(`if _, err := `, qualifiedFunc(assocType, "DefaultApplyFieldMask"), `(ctx, patchee.`, fieldName, `, patcher.`, fieldName,
+			`, updateMask, prefix+"`, string(field.Name()), `.", db); err != nil {`)
+		p.P(`return nil, err`)
+		p.P(`}`)
+		p.P(`continue`)
+		p.P(`}`)
+	}
+	if len(nested) > 0 {
+		p.UsingGoImports(stdStringsImport)
+	}
+	p.P(`}`)
+	p.P(`return patchee, nil`)
+	p.P(`}`)
+	p.P()
+}
+
+func (p *OrmPlugin) generatePatchHandler(message pgs.Message, ormable *OrmableType) {
+	typeName := p.TypeName(message)
+	p.P(`// DefaultPatch`, typeName, ` executes a basic gorm update call with patch behavior:`)
+	p.P(`// the stored object is read, the paths of updateMask are copied over from in`)
+	p.P(`// and only the matching columns are written back`)
+	p.P(`func DefaultPatch`, typeName, `(ctx context.Context, in *`, typeName, `, updateMask *`, p.Import(fieldmaskImport), `.FieldMask, db *`, p.Import(gormImport), `.DB) (*`, typeName, `, error) {`)
+	p.P(`if in == nil {`)
+	p.P(`return nil, `, p.Import(gerrorsImport), `.NilArgumentError`)
+	p.P(`}`)
+	p.P(`ormObj, err := in.ToORM(ctx)`)
+	p.P(`if err != nil {`)
+	p.P(`return nil, err`)
+	p.P(`}`)
+	p.generateEmptyIdCheck(ormable)
+	p.generateBeforeHookCall(typeName, "Patch")
+	p.P(`ormPatchee := `, ormable.Name, `{}`)
+	// the nested associations are loaded too, so a nested path patches the
+	// stored record instead of creating another one
+	preloads := ""
+	for _, preload := range p.nestedPreloads(message, "", map[string]bool{}) {
+		preloads += `.Preload("` + preload + `")`
+	}
+	p.P(`if err = db.Preload(`, p.Import(gormClauseImport), `.Associations)`, preloads, `.Where(&`, p.keyLiteral(ormable), `).First(&ormPatchee).Error; err != nil {`)
+	p.P(`return nil, err`)
+	p.P(`}`)
+	p.P(`pbObj, err := ormPatchee.ToPB(ctx)`)
+	p.P(`if err != nil {`)
+	p.P(`return nil, err`)
+	p.P(`}`)
+	p.P(`if _, err = DefaultApplyFieldMask`, typeName, `(ctx, &pbObj, in, updateMask, "", db); err != nil {`)
+	p.P(`return nil, err`)
+	p.P(`}`)
+	p.P(`if ormObj, err = pbObj.ToORM(ctx); err != nil {`)
+	p.P(`return nil, err`)
+	p.P(`}`)
+	p.P(`if err = DefaultPatchColumns`, typeName, `(ctx, &ormObj, updateMask, "", db); err != nil {`)
+	p.P(`return nil, err`)
+	p.P(`}`)
+	p.generateResponse("ormObj")
+	p.generateAfterHookCall(typeName, "Patch", "ormObj", "&pbResponse")
+	p.P(`return &pbResponse, nil`)
+	p.P(`}`)
+	p.P()
+	p.generateHandlerHookInterfaces(typeName, "Patch", "*"+typeName)
+}
+
+// nestedPreloads returns the preload paths of the has-one and belongs-to
+// associations nested in those of a message, which nested mask paths can
+// reach. A path ends at a type met before along it.
+func (p *OrmPlugin) nestedPreloads(message pgs.Message, prefix string, seen map[string]bool) []string {
+	var preloads []string
+	seen[message.FullyQualifiedName()] = true
+	ormable := p.getOrmable(message)
+	for _, field := range message.Fields() {
+		if getFieldOptions(field).GetDrop() || inRealOneOf(field) {
+			continue
+		}
+		fieldName := generator.CamelCase(string(field.Name()))
+		assoc := p.singularAssociation(field, ormable.Fields[fieldName])
+		if assoc == nil || !p.hasPrimaryKey(assoc) || seen[field.Type().Embed().FullyQualifiedName()] {
+			continue
+		}
+		if prefix != "" {
+			preloads = append(preloads, prefix+fieldName)
+		}
+		preloads = append(preloads, p.nestedPreloads(field.Type().Embed(), prefix+fieldName+".", seen)...)
+	}
+	delete(seen, message.FullyQualifiedName())
+	return preloads
+}
+
+// generatePatchColumns outputs the function writing the columns listed in a
+// field mask. Nested paths of has-one and belongs-to associations are written
+// to the associated record, which is created with its own nested paths if it
+// does not exist yet. Whole associations listed in the mask are replaced.
+// Nested paths which cannot be written are rejected before the record is.
+func (p *OrmPlugin) generatePatchColumns(message pgs.Message) {
+	typeName := p.TypeName(message)
+	ormable := p.getOrmable(message)
+
+	p.P(`// DefaultPatchColumns`, typeName, ` writes the columns of ormObj listed in updateMask to the DB,`)
+	p.P(`// creating the record if it has no primary key yet`)
+	p.P(`func DefaultPatchColumns`, typeName, `(ctx context.Context, ormObj *`, ormable.Name,
+		`, updateMask *`, p.Import(fieldmaskImport), `.FieldMask, prefix string, db *`, p.Import(gormImport), `.DB) error {`)
+	p.P(`if ormObj == nil {`)
+	p.P(`return `, p.Import(gerrorsImport), `.NilArgumentError`)
+	p.P(`}`)
+	p.P(`columns := []string{}`)
+	for _, ofield := range ormable.Fields {
+		if isAssociation(ofield) {
+			p.P(`associations := map[string]bool{}`)
+			break
+		}
+	}
+
+	type nestedAssoc struct {
+		field  pgs.Field
+		ofield *Field
+		assoc  *OrmableType
+	}
+	// the nested paths of associations without a primary key are rejected,
+	// their record cannot be looked up to be written
+	var nested, keyless []nestedAssoc
+	for _, field := range message.Fields() {
+		if getFieldOptions(field).GetDrop() {
+			continue
+		}
+		ofield := ormable.Fields[generator.CamelCase(string(field.Name()))]
+		if assoc := p.singularAssociation(field, ofield); assoc == nil || inRealOneOf(field) {
+			continue
+		} else if !p.hasPrimaryKey(assoc) {
+			keyless = append(keyless, nestedAssoc{field, ofield, assoc})
+		} else {
+			nested = append(nested, nestedAssoc{field, ofield, assoc})
+			p.P(`var patched`, generator.CamelCase(string(field.Name())), ` bool`)
+		}
+	}
+
+	p.P(`for _, f := range updateMask.GetPaths() {`)
+	p.P(`switch {`)
+	// Setting any member of a oneof clears the others, so all its members are
+	// written along with its case field
+	var oneofs []pgs.OneOf
+	for _, field := range message.Fields() {
+		if getFieldOptions(field).GetDrop() {
+			continue
+		}
+		fieldName := generator.CamelCase(string(field.Name()))
+		ofield, ok := ormable.Fields[fieldName]
+		if !ok {
+			continue
+		}
+		if inRealOneOf(field) {
+			if members := oneofMembers(ormable, field.OneOf()); members[0].Name() == field.Name() {
+				oneofs = append(oneofs, field.OneOf())
+			}
+			continue
+		}
+		p.P(`case f == prefix+"`, string(field.Name()), `":`)
+		if isAssociation(ofield) {
+			p.P(`associations["`, fieldName, `"] = true`)
+		} else {
+			p.P(`columns = append(columns, "`, fieldName, `")`)
+		}
+	}
+	for _, oneof := range oneofs {
+		members := oneofMembers(ormable, oneof)
+		paths := []string{`f == prefix+"` + string(oneof.Name()) + `"`}
+		for _, field := range members {
+			paths = append(paths, `f == prefix+"`+string(field.Name())+`"`)
+		}
+		p.P(`case `, strings.Join(paths, ", "), `:`)
+		for _, field := range members {
+			fieldName := generator.CamelCase(string(field.Name()))
+			if isAssociation(ormable.Fields[fieldName]) {
+				p.P(`associations["`, fieldName, `"] = true`)
+			} else {
+				p.P(`columns = append(columns, "`, fieldName, `")`)
+			}
+		}
+		p.P(`columns = append(columns, "`, oneofCaseName(oneof), `")`)
+	}
+	// a nested path of an association the object does not hold would be
+	// dropped, the patcher must hold the association
+	for _, n := range nested {
+		fieldName := generator.CamelCase(string(n.field.Name()))
+		p.P(`case strings.HasPrefix(f, prefix+"`, string(n.field.Name()), `."):`)
+		p.P(`if ormObj.`, fieldName, ` == nil {`)
+		p.P(`return `, p.Import(grpcStatusImport), `.Errorf(`, p.Import(grpcCodesImport), `.InvalidArgument, "the path %s of the update mask needs the `, string(n.field.Name()), ` of the patcher", f)`)
+		p.P(`}`)
+		p.P(`patched`, fieldName, ` = true`)
+	}
+	for _, n := range keyless {
+		p.P(`case strings.HasPrefix(f, prefix+"`, string(n.field.Name()), `."):`)
+		p.P(`return `, p.Import(grpcStatusImport), `.Errorf(`, p.Import(grpcCodesImport), `.InvalidArgument, "the path %s of the update mask cannot be written, `, n.assoc.Name, ` has no primary key", f)`)
+	}
+	if len(nested) > 0 || len(keyless) > 0 {
+		p.UsingGoImports(stdStringsImport)
+	}
+	p.P(`}`)
+	p.P(`}`)
+
+	// belongs-to records are written first, so the foreign key of ormObj can
+	// point to a newly created record
+	for _, n := range nested {
+		belongsTo := n.ofield.GetBelongsTo()
+		if belongsTo == nil {
+			continue
+		}
+		fieldName := generator.CamelCase(string(n.field.Name()))
+		assocType := p.fieldTypeName(n.field)
+		p.P(`if patched`, fieldName, ` {`)
+		p.P(`if err := `, qualifiedFunc(assocType, "DefaultPatchColumns"), `(ctx, ormObj.`, fieldName, `, updateMask, prefix+"`, string(n.field.Name()), `.", db); err != nil {`)
+		p.P(`return err`)
+		p.P(`}`)
+		references := keyNames(belongsTo.GetReferences())
+		for i, foreignKey := range keyNames(belongsTo.GetForeignKey()) {
+			p.generateKeyAssignment(`ormObj.`+foreignKey, ormable.Fields[foreignKey],
+				`ormObj.`+fieldName+`.`+references[i], n.assoc.Fields[references[i]])
+			p.P(`columns = append(columns, "`, foreignKey, `")`)
+		}
+		p.P(`}`)
+	}
+
+	// a new record is created before its associations are written
+	p.P(`if `, p.emptyKeyCondition(ormable, "ormObj"), ` {`)
+	p.P(`if err := db.Omit(`, p.Import(gormClauseImport), `.Associations).Create(ormObj).Error; err != nil {`)
+	p.P(`return err`)
+	p.P(`}`)
+	p.P(`} else if len(columns) > 0 {`)
+	p.P(`if err := db.Model(ormObj).Select(columns).Updates(ormObj).Error; err != nil {`)
+	p.P(`return err`)
+	p.P(`}`)
+	p.P(`}`)
+
+	for _, fieldName := range ormable.FieldsOrder {
+		ofield := ormable.Fields[fieldName]
+		if !isAssociation(ofield) {
+			continue
+		}
+		p.P(`if associations["`, fieldName, `"] {`)
+		if strings.HasPrefix(ofield.Type, "[]") {
+			p.P(`if err := db.Model(ormObj).Association("`, fieldName, `").Replace(ormObj.`, fieldName, `); err != nil {`)
+			p.P(`return err`)
+			p.P(`}`)
+		} else {
+			p.P(`if ormObj.`, fieldName, ` != nil {`)
+			p.P(`if err := db.Model(ormObj).Association("`, fieldName, `").Replace(ormObj.`, fieldName, `); err != nil {`)
+			p.P(`return err`)
+			p.P(`}`)
+			p.P(`} else if err := db.Model(ormObj).Association("`, fieldName, `").Clear(); err != nil {`)
+			p.P(`return err`)
+			p.P(`}`)
+		}
+		p.P(`}`)
+	}
+
+	for _, n := range nested {
+		hasOne := n.ofield.GetHasOne()
+		if hasOne == nil {
+			continue
+		}
+		fieldName := generator.CamelCase(string(n.field.Name()))
+		assocType := p.fieldTypeName(n.field)
+		p.P(`if patched`, fieldName, ` {`)
+		references := keyNames(hasOne.GetReferences())
+		for i, foreignKey := range keyNames(hasOne.GetForeignKey()) {
+			p.generateKeyAssignment(`ormObj.`+fieldName+`.`+foreignKey, n.assoc.Fields[foreignKey],
+				`ormObj.`+references[i], ormable.Fields[references[i]])
+		}
+		p.P(`if err := `, qualifiedFunc(assocType, "DefaultPatchColumns"), `(ctx, ormObj.`, fieldName, `, updateMask, prefix+"`, string(n.field.Name()), `.", db); err != nil {`)
+		p.P(`return err`)
+		p.P(`}`)
+		p.P(`}`)
+	}
+	p.P(`return nil`)
+	p.P(`}`)
+	p.P()
+}
+
+func (p *OrmPlugin) generatePatchSetHandler(typeName string) {
+	p.P(`// DefaultPatchSet`, typeName, ` executes a bulk gorm update call with patch behavior,`)
+	p.P(`// applying updateMasks[i] to objects[i]`)
+	p.P(`func DefaultPatchSet`, typeName, `(ctx context.Context, objects []*`, typeName, `, updateMasks []*`, p.Import(fieldmaskImport), `.FieldMask, db *`, p.Import(gormImport), `.DB) ([]*`, typeName, `, error) {`)
+	p.P(`if len(objects) != len(updateMasks) {`)
+	p.UsingGoImports(stdFmtImport)
+	p.P(`return nil, fmt.Errorf(`, p.Import(gerrorsImport), `.BadRepeatedFieldMaskTpl, len(updateMasks), len(objects))`)
+	p.P(`}`)
+	p.P(`results := make([]*`, typeName, `, 0, len(objects))`)
+	p.P(`for i, patcher := range objects {`)
+	p.P(`pbResponse, err := DefaultPatch`, typeName, `(ctx, patcher, updateMasks[i], db)`)
+	p.P(`if err != nil {`)
+	p.P(`return nil, err`)
+	p.P(`}`)
+	p.P(`results = append(results, pbResponse)`)
+	p.P(`}`)
+	p.P(`return results, nil`)
+	p.P(`}`)
+	p.P()
+}
+
+// generateKeyAssignment outputs the assignment of a key value to a foreign key,
+// taking the address of the value when only the foreign key is a pointer
+func (p *OrmPlugin) generateKeyAssignment(dst string, dstField *Field, src string, srcField *Field) {
+	if dstField != nil && srcField != nil && strings.HasPrefix(dstField.Type, "*") && !strings.HasPrefix(srcField.Type, "*") {
+		p.P(`{`)
+		p.P(`key := `, src)
+		p.P(dst, ` = &key`)
+		p.P(`}`)
+		return
+	}
+	if dstField != nil && srcField != nil && !strings.HasPrefix(dstField.Type, "*") && strings.HasPrefix(srcField.Type, "*") {
+		p.P(`if `, src, ` != nil {`)
+		p.P(dst, ` = *`, src)
+		p.P(`}`)
+		return
+	}
+	p.P(dst, ` = `, src)
+}
+
+// isAssociation tells if the ORM field holds an association to another ormable type
+func isAssociation(field *Field) bool {
+	return field.GetHasOne() != nil || field.GetBelongsTo() != nil || field.GetHasMany() != nil || field.GetManyToMany() != nil
+}
+
+// qualifiedFunc returns the name of a generated function for the given type,
+// keeping the package qualifier of types from other packages
+func qualifiedFunc(typeName string, prefix string) string {
+	if i := strings.LastIndex(typeName, "."); i >= 0 {
+		return typeName[:i+1] + prefix + typeName[i+1:]
+	}
+	return prefix + typeName
+}
@@ -0,0 +1,88 @@
+package plugin
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// formatSource removes the unused imports of a generated file and formats it
+// like gofmt. The generation fails if the file does not parse, reporting the
+// position and the text of the offending line.
+func (p *OrmPlugin) formatSource(fileName string, src []byte) string {
+	fset := token.NewFileSet()
+	file := p.parseSource(fset, fileName, src)
+	// the lines of the unused imports are removed from the source, so they
+	// leave no gap splitting the import groups
+	if unused := unusedImportLines(fset, file); len(unused) > 0 {
+		var kept [][]byte
+		for i, line := range bytes.Split(src, []byte("\n")) {
+			if !unused[i+1] {
+				kept = append(kept, line)
+			}
+		}
+		fset = token.NewFileSet()
+		file = p.parseSource(fset, fileName, bytes.Join(kept, []byte("\n")))
+		var decls []ast.Decl
+		for _, decl := range file.Decls {
+			if gen, ok := decl.(*ast.GenDecl); !ok || gen.Tok != token.IMPORT || len(gen.Specs) > 0 {
+				decls = append(decls, decl)
+			}
+		}
+		file.Decls = decls
+	}
+	ast.SortImports(fset, file)
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		p.Failf("cannot format %s: %s", fileName, err)
+	}
+	return buf.String()
+}
+
+func (p *OrmPlugin) parseSource(fset *token.FileSet, fileName string, src []byte) *ast.File {
+	file, err := parser.ParseFile(fset, fileName, src, parser.ParseComments)
+	if err != nil {
+		if list, ok := err.(scanner.ErrorList); ok && len(list) > 0 {
+			lines := strings.Split(string(src), "\n")
+			if line := list[0].Pos.Line; line > 0 && line <= len(lines) {
+				p.Failf("generated code does not parse: %s in line: %s", list[0].Error(), strings.TrimSpace(lines[line-1]))
+			}
+		}
+		p.Failf("generated code does not parse: %s", err)
+	}
+	return file
+}
+
+// unusedImportLines returns the lines of the imports whose name is never the
+// package of a selector. Unaliased imports are assumed to be named after the
+// last element of their path.
+func unusedImportLines(fset *token.FileSet, file *ast.File) map[int]bool {
+	used := map[string]bool{}
+	ast.Inspect(file, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			// identifiers resolved by the parser are local, packages are not
+			if id, ok := sel.X.(*ast.Ident); ok && id.Obj == nil {
+				used[id.Name] = true
+			}
+		}
+		return true
+	})
+	unused := map[int]bool{}
+	for _, spec := range file.Imports {
+		importPath, _ := strconv.Unquote(spec.Path.Value)
+		name := path.Base(importPath)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		if name != "_" && name != "." && !used[name] {
+			unused[fset.Position(spec.Pos()).Line] = true
+		}
+	}
+	return unused
+}
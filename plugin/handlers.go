@@ -0,0 +1,285 @@
+package plugin
+
+import (
+	"strings"
+
+	jgorm "github.com/jinzhu/gorm"
+	pgs "github.com/lyft/protoc-gen-star"
+)
+
+// generateDefaultHandlers outputs the DefaultCreate/Read/Update/Delete/List
+// functions for an ormable type. Handlers working with a single existing
+// record are only generated if the type has a primary key.
+func (p *OrmPlugin) generateDefaultHandlers(message pgs.Message) {
+	typeName := p.TypeName(message)
+	ormable := p.getOrmable(message)
+
+	p.generateCreateHandler(typeName)
+	if p.hasPrimaryKey(ormable) {
+		p.generateReadHandler(typeName, ormable)
+		p.generateUpdateHandler(typeName, ormable)
+		p.generateDeleteHandler(typeName, ormable)
+	}
+	p.generateListHandler(typeName, ormable)
+	p.generatePatchHandlers(message)
+}
+
+func (p *OrmPlugin) generateCreateHandler(typeName string) {
+	p.P(`// DefaultCreate`, typeName, ` executes a basic gorm create call`)
+	p.P(`func DefaultCreate`, typeName, `(ctx context.Context, in *`, typeName, `, db *`, p.Import(gormImport), `.DB) (*`, typeName, `, error) {`)
+	p.P(`if in == nil {`)
+	p.P(`return nil, `, p.Import(gerrorsImport), `.NilArgumentError`)
+	p.P(`}`)
+	p.P(`ormObj, err := in.ToORM(ctx)`)
+	p.P(`if err != nil {`)
+	p.P(`return nil, err`)
+	p.P(`}`)
+	p.generateBeforeHookCall(typeName, "Create")
+	p.P(`if err = db.Create(&ormObj).Error; err != nil {`)
+	p.P(`return nil, err`)
+	p.P(`}`)
+	p.generateResponse("ormObj")
+	p.generateAfterHookCall(typeName, "Create", "ormObj", "&pbResponse")
+	p.P(`return &pbResponse, nil`)
+	p.P(`}`)
+	p.P()
+	p.generateHandlerHookInterfaces(typeName, "Create", "*"+typeName)
+}
+
+func (p *OrmPlugin) generateReadHandler(typeName string, ormable *OrmableType) {
+	p.P(`// DefaultRead`, typeName, ` executes a basic gorm read call, looking the object up by its primary key`)
+	p.P(`func DefaultRead`, typeName, `(ctx context.Context, in *`, typeName, `, db *`, p.Import(gormImport), `.DB) (*`, typeName, `, error) {`)
+	p.P(`if in == nil {`)
+	p.P(`return nil, `, p.Import(gerrorsImport), `.NilArgumentError`)
+	p.P(`}`)
+	p.P(`ormObj, err := in.ToORM(ctx)`)
+	p.P(`if err != nil {`)
+	p.P(`return nil, err`)
+	p.P(`}`)
+	p.generateEmptyIdCheck(ormable)
+	p.generateScopeDeleted(ormable)
+	p.generateBeforeHookCall(typeName, "Read")
+	p.P(`ormResponse := `, ormable.Name, `{}`)
+	p.P(`if err = db.Where(&`, p.keyLiteral(ormable), `).First(&ormResponse).Error; err != nil {`)
+	p.P(`return nil, err`)
+	p.P(`}`)
+	p.generateResponse("ormResponse")
+	p.generateAfterHookCall(typeName, "Read", "ormResponse", "&pbResponse")
+	p.P(`return &pbResponse, nil`)
+	p.P(`}`)
+	p.P()
+	p.generateHandlerHookInterfaces(typeName, "Read", "*"+typeName)
+}
+
+func (p *OrmPlugin) generateUpdateHandler(typeName string, ormable *OrmableType) {
+	p.P(`// DefaultUpdate`, typeName, ` executes a basic gorm update call, overwriting every column of the object`)
+	p.P(`func DefaultUpdate`, typeName, `(ctx context.Context, in *`, typeName, `, db *`, p.Import(gormImport), `.DB) (*`, typeName, `, error) {`)
+	p.P(`if in == nil {`)
+	p.P(`return nil, `, p.Import(gerrorsImport), `.NilArgumentError`)
+	p.P(`}`)
+	p.P(`ormObj, err := in.ToORM(ctx)`)
+	p.P(`if err != nil {`)
+	p.P(`return nil, err`)
+	p.P(`}`)
+	p.generateEmptyIdCheck(ormable)
+	p.generateKeepDeleted(ormable)
+	p.generateBeforeHookCall(typeName, "Update")
+	p.P(`if err = db.Save(&ormObj).Error; err != nil {`)
+	p.P(`return nil, err`)
+	p.P(`}`)
+	p.generateResponse("ormObj")
+	p.generateAfterHookCall(typeName, "Update", "ormObj", "&pbResponse")
+	p.P(`return &pbResponse, nil`)
+	p.P(`}`)
+	p.P()
+	p.generateHandlerHookInterfaces(typeName, "Update", "*"+typeName)
+}
+
+func (p *OrmPlugin) generateDeleteHandler(typeName string, ormable *OrmableType) {
+	p.P(`// DefaultDelete`, typeName, ` executes a basic gorm delete call, looking the object up by its primary key`)
+	p.P(`func DefaultDelete`, typeName, `(ctx context.Context, in *`, typeName, `, db *`, p.Import(gormImport), `.DB) error {`)
+	p.P(`if in == nil {`)
+	p.P(`return `, p.Import(gerrorsImport), `.NilArgumentError`)
+	p.P(`}`)
+	p.P(`ormObj, err := in.ToORM(ctx)`)
+	p.P(`if err != nil {`)
+	p.P(`return err`)
+	p.P(`}`)
+	p.generateEmptyIdCheck(ormable, "err")
+	p.generateBeforeHookCall(typeName, "Delete", "err")
+	p.P(`if err = db.Where(&`, p.keyLiteral(ormable), `).Delete(&`, ormable.Name, `{}).Error; err != nil {`)
+	p.P(`return err`)
+	p.P(`}`)
+	p.generateAfterHookCall(typeName, "Delete", "ormObj", "")
+	p.P(`return nil`)
+	p.P(`}`)
+	p.P()
+	p.generateHandlerHookInterfaces(typeName, "Delete", "")
+}
+
+func (p *OrmPlugin) generateListHandler(typeName string, ormable *OrmableType) {
+	p.P(`// DefaultList`, typeName, ` executes a basic gorm find call, ordered by the primary key when there is one`)
+	p.P(`func DefaultList`, typeName, `(ctx context.Context, db *`, p.Import(gormImport), `.DB) ([]*`, typeName, `, error) {`)
+	p.P(`var err error`)
+	p.P(`ormObj := `, ormable.Name, `{}`)
+	p.generateScopeDeleted(ormable)
+	p.generateBeforeHookCall(typeName, "List")
+	if p.hasPrimaryKey(ormable) {
+		var columns []string
+		for _, pkName := range p.primaryKeys(ormable) {
+			columns = append(columns, columnName(pkName, ormable.Fields[pkName]))
+		}
+		p.P(`db = db.Order("`, strings.Join(columns, ", "), `")`)
+	}
+	p.P(`ormResponse := []`, ormable.Name, `{}`)
+	p.P(`if err = db.Find(&ormResponse).Error; err != nil {`)
+	p.P(`return nil, err`)
+	p.P(`}`)
+	p.P(`pbResponse := []*`, typeName, `{}`)
+	p.P(`for _, responseEntry := range ormResponse {`)
+	p.P(`temp, err := responseEntry.ToPB(ctx)`)
+	p.P(`if err != nil {`)
+	p.P(`return nil, err`)
+	p.P(`}`)
+	p.P(`pbResponse = append(pbResponse, &temp)`)
+	p.P(`}`)
+	p.generateAfterHookCall(typeName, "List", "ormObj", "&pbResponse")
+	p.P(`return pbResponse, nil`)
+	p.P(`}`)
+	p.P()
+	p.generateHandlerHookInterfaces(typeName, "List", "*[]*"+typeName)
+}
+
+// generateEmptyIdCheck outputs the EmptyIdError guard for the primary keys of
+// ormObj. The optional argument is the list of values returned before the error.
+func (p *OrmPlugin) generateEmptyIdCheck(ormable *OrmableType, ret ...string) {
+	p.P(`if `, p.emptyKeyCondition(ormable, "ormObj"), ` {`)
+	p.P(`return `, handlerReturn(ret, p.Import(gerrorsImport)+".EmptyIdError"))
+	p.P(`}`)
+}
+
+// emptyKeyCondition returns the condition telling if any primary key of obj
+// is unset. GORM ignores the zero fields of a struct condition, so a key
+// whose unset value cannot be told would look up any record.
+func (p *OrmPlugin) emptyKeyCondition(ormable *OrmableType, obj string) string {
+	var conditions []string
+	for _, pkName := range p.primaryKeys(ormable) {
+		pk := ormable.Fields[pkName]
+		condition := emptyValue(obj+`.`+pkName, pk.Type)
+		if condition == "" {
+			p.Failf("the primary key %s of %s cannot be checked for an unset value, its type %s is not supported as a key", pkName, ormable.Name, pk.Type)
+		}
+		conditions = append(conditions, condition)
+	}
+	return strings.Join(conditions, " || ")
+}
+
+// keyLiteral returns the ORM literal holding the primary keys of ormObj, the
+// condition looking the object up
+func (p *OrmPlugin) keyLiteral(ormable *OrmableType) string {
+	var keys []string
+	for _, pkName := range p.primaryKeys(ormable) {
+		keys = append(keys, pkName+`: ormObj.`+pkName)
+	}
+	return ormable.Name + `{` + strings.Join(keys, ", ") + `}`
+}
+
+func (p *OrmPlugin) generateBeforeHookCall(typeName string, action string, ret ...string) {
+	p.P(`if hook, ok := interface{}(&ormObj).(`, typeName, `ORMWithBefore`, action, `_); ok {`)
+	p.P(`if db, err = hook.Before`, action, `_(ctx, db); err != nil {`)
+	p.P(`return `, handlerReturn(ret, "err"))
+	p.P(`}`)
+	p.P(`}`)
+}
+
+// generateResponse outputs the conversion of the stored ORM object to the
+// pbResponse returned by a handler
+func (p *OrmPlugin) generateResponse(ormObj string) {
+	p.P(`pbResponse, err := `, ormObj, `.ToPB(ctx)`)
+	p.P(`if err != nil {`)
+	p.P(`return nil, err`)
+	p.P(`}`)
+}
+
+// generateAfterHookCall outputs the call of the After hook of a handler on the
+// ORM object stored or read. The hook is given the address of the response so
+// it can change what the handler returns, handlers returning no object pass
+// an empty response and return the error alone.
+func (p *OrmPlugin) generateAfterHookCall(typeName string, action string, ormObj string, response string) {
+	var ret []string
+	args := "ctx, db"
+	if response == "" {
+		ret = []string{"err"}
+	} else {
+		args = "ctx, " + response + ", db"
+	}
+	p.P(`if hook, ok := interface{}(&`, ormObj, `).(`, typeName, `ORMWithAfter`, action, `_); ok {`)
+	p.P(`if err = hook.After`, action, `_(`, args, `); err != nil {`)
+	p.P(`return `, handlerReturn(ret, "err"))
+	p.P(`}`)
+	p.P(`}`)
+}
+
+// generateHandlerHookInterfaces outputs the Before and After hooks of a
+// handler, responseType is the type of the response given to the After hook,
+// empty for handlers returning no object
+func (p *OrmPlugin) generateHandlerHookInterfaces(typeName string, action string, responseType string) {
+	p.P(`// `, typeName, `ORMWithBefore`, action, `_ called before Default`, action, typeName, ` runs the query`)
+	p.P(`type `, typeName, `ORMWithBefore`, action, `_ interface {`)
+	p.P(`Before`, action, `_(context.Context, *`, p.Import(gormImport), `.DB) (*`, p.Import(gormImport), `.DB, error)`)
+	p.P(`}`)
+	p.P()
+	p.P(`// `, typeName, `ORMWithAfter`, action, `_ called after Default`, action, typeName, ` runs the query`)
+	if responseType != "" {
+		p.P(`// and converts the result, the response may be changed`)
+	}
+	p.P(`type `, typeName, `ORMWithAfter`, action, `_ interface {`)
+	if responseType == "" {
+		p.P(`After`, action, `_(context.Context, *`, p.Import(gormImport), `.DB) error`)
+	} else {
+		p.P(`After`, action, `_(context.Context, `, responseType, `, *`, p.Import(gormImport), `.DB) error`)
+	}
+	p.P(`}`)
+	p.P()
+}
+
+// handlerReturn builds the values of a return statement: ret is empty for
+// handlers returning an object and an error, otherwise the error is returned alone
+func handlerReturn(ret []string, err string) string {
+	if len(ret) == 0 {
+		return "nil, " + err
+	}
+	return err
+}
+
+// emptyValue returns the condition telling if the value of a field of the
+// given ORM type is unset, or "" if it cannot be determined: a false bool is
+// a value
+func emptyValue(value string, fieldType string) string {
+	switch {
+	case fieldType == "[]byte":
+		return `len(` + value + `) == 0`
+	case strings.HasPrefix(fieldType, "*"), strings.HasPrefix(fieldType, "[]"), fieldType == "interface{}":
+		return value + ` == nil`
+	case fieldType == "string":
+		return value + ` == ""`
+	case fieldType == "bool":
+		return ""
+	case strings.HasSuffix(fieldType, ".UUID"):
+		return value + ` == ` + strings.TrimSuffix(fieldType, ".UUID") + ".Nil"
+	case fieldType == "time.Time":
+		return value + `.IsZero()`
+	}
+	if _, ok := builtinTypes[fieldType]; ok {
+		return value + ` == 0`
+	}
+	return ""
+}
+
+// columnName returns the DB column of an ORM field
+func columnName(fieldName string, field *Field) string {
+	if column := field.GetTag().GetColumn(); column != "" {
+		return column
+	}
+	return jgorm.ToDBName(fieldName)
+}
@@ -0,0 +1,105 @@
+package plugin
+
+import (
+	"strings"
+	"testing"
+
+	pgs "github.com/lyft/protoc-gen-star"
+
+	gorm "github.com/TheSDTM/protoc-gen-gorm/options"
+)
+
+func newTestPlugin() *OrmPlugin {
+	return &OrmPlugin{fileImports: map[pgs.File]*fileImports{}}
+}
+
+// testOrmable returns the ormable type of a message with an id key and,
+// given a soft delete mode, the mark of its soft deleted rows
+func testOrmable(mode gorm.SoftDeleteMode) *OrmableType {
+	ormable := NewOrmableType("Note", nil)
+	ormable.Name = "NoteORM"
+	ormable.Fields["Id"] = &Field{Type: "uint64", GormFieldOptions: &gorm.GormFieldOptions{}}
+	ormable.Fields["Text"] = &Field{Type: "string", GormFieldOptions: &gorm.GormFieldOptions{}}
+	ormable.FieldsOrder = append(ormable.FieldsOrder, "Id", "Text")
+	if mode != 0 {
+		ormable.Fields["DeletedAt"] = &Field{Type: "gorm.DeletedAt", Package: gormImport, SoftDelete: mode, GormFieldOptions: &gorm.GormFieldOptions{}}
+		ormable.FieldsOrder = append(ormable.FieldsOrder, "DeletedAt")
+	}
+	return ormable
+}
+
+func TestGenerateUpdateHandler(t *testing.T) {
+	lookup := "if err = db.Where(&NoteORM{Id: ormObj.Id}).First(&stored).Error; err != nil {"
+	cases := []struct {
+		name string
+		mode gorm.SoftDeleteMode
+		// before lists the statements expected before the save in this order,
+		// none of them is expected without a soft delete mark
+		before []string
+	}{
+		{"not soft deleted", 0, nil},
+		{"deleted at", gorm.SoftDeleteMode_DELETED_AT, []string{
+			"db = gorm1.ScopeDeleted(ctx, db)",
+			lookup,
+			"ormObj.DeletedAt = stored.DeletedAt",
+		}},
+		{"flag", gorm.SoftDeleteMode_FLAG, []string{
+			"db = gorm1.ScopeDeleted(ctx, db)",
+			lookup,
+			"ormObj.DeletedAt = stored.DeletedAt",
+		}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			p := newTestPlugin()
+			p.generateUpdateHandler("Note", testOrmable(c.mode))
+			code := strings.Join(p.currentFileBuffer, "")
+			save := strings.Index(code, "db.Save(&ormObj)")
+			if save < 0 {
+				t.Fatalf("Expected the object to be saved, got:\n%s", code)
+			}
+			if c.before == nil && strings.Contains(code, "stored") {
+				t.Errorf("Expected no lookup of the stored row, got:\n%s", code)
+			}
+			last := 0
+			for _, statement := range c.before {
+				i := strings.Index(code, statement)
+				if i < last || i > save {
+					t.Fatalf("Expected %q before the save in order, got:\n%s", statement, code)
+				}
+				last = i
+			}
+		})
+	}
+}
+
+func TestGenerateAfterHookCall(t *testing.T) {
+	cases := []struct {
+		name     string
+		generate func(p *OrmPlugin)
+		// call is the expected hook call, made after the conversion of the
+		// response and before the return
+		call string
+		ret  string
+	}{
+		{"create", func(p *OrmPlugin) { p.generateCreateHandler("Note") }, "hook.AfterCreate_(ctx, &pbResponse, db)", "return &pbResponse, nil"},
+		{"read", func(p *OrmPlugin) { p.generateReadHandler("Note", testOrmable(0)) }, "hook.AfterRead_(ctx, &pbResponse, db)", "return &pbResponse, nil"},
+		{"update", func(p *OrmPlugin) { p.generateUpdateHandler("Note", testOrmable(0)) }, "hook.AfterUpdate_(ctx, &pbResponse, db)", "return &pbResponse, nil"},
+		{"list", func(p *OrmPlugin) { p.generateListHandler("Note", testOrmable(0)) }, "hook.AfterList_(ctx, &pbResponse, db)", "return pbResponse, nil"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			p := newTestPlugin()
+			c.generate(p)
+			code := strings.Join(p.currentFileBuffer, "")
+			conversion := strings.Index(code, ".ToPB(ctx)")
+			call := strings.Index(code, c.call)
+			ret := strings.Index(code, c.ret)
+			if conversion < 0 || call < conversion || ret < call {
+				t.Errorf("Expected %q between the conversion and %q, got:\n%s", c.call, c.ret, code)
+			}
+		})
+	}
+}
@@ -1,5 +1,14 @@
 package plugin
 
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	pgs "github.com/lyft/protoc-gen-star"
+)
+
 // /* --------- Response file import cleaning -------- */
 
 // // Imports that are added by default but unneeded in GORM code
@@ -39,74 +48,193 @@ var (
 	stdStringsImport   = "strings"
 	stdTimeImport      = "time"
 	encodingJsonImport = "encoding/json"
+	gormImport         = "gorm.io/gorm"
+	gormClauseImport   = "gorm.io/gorm/clause"
+	grpcStatusImport   = "google.golang.org/grpc/status"
+	grpcCodesImport    = "google.golang.org/grpc/codes"
+	softDeleteImport   = "gorm.io/plugin/soft_delete"
+	fieldmaskImport    = "google.golang.org/protobuf/types/known/fieldmaskpb"
+	structpbImport     = "google.golang.org/protobuf/types/known/structpb"
+	gerrorsImport      = "github.com/TheSDTM/protoc-gen-gorm/errors"
+	tgormImport        = "github.com/TheSDTM/protoc-gen-gorm/gorm"
+	protojsonImport    = "google.golang.org/protobuf/encoding/protojson"
+	protoImport        = "google.golang.org/protobuf/proto"
 )
 
-// type pkgImport struct {
-// 	packagePath string
-// 	alias       string
-// }
+type pkgImport struct {
+	packagePath string
+	alias       string
+}
 
-// // Import takes a package and adds it to the list of packages to import
-// // It will generate a unique new alias using the last portion of the import path
-// // unless the package is already imported for this file. Either way, it returns
-// // the package alias
-// func (p *OrmPlugin) Import(packagePath string) string {
-// 	subpath := packagePath[strings.LastIndex(packagePath, "/")+1:]
-// 	// package will always be suffixed with an integer to prevent any collisions
-// 	// with standard package imports
-// 	for i := 1; ; i++ {
-// 		newAlias := fmt.Sprintf("%s%d", strings.Replace(subpath, ".", "_", -1), i)
-// 		if pkg, ok := p.GetFileImports().packages[newAlias]; ok {
-// 			if packagePath == pkg.packagePath {
-// 				return pkg.alias
-// 			}
-// 		} else {
-// 			p.GetFileImports().packages[newAlias] = &pkgImport{packagePath: packagePath, alias: newAlias}
-// 			return newAlias
-// 		}
-// 	}
-// 	// Should never reach here
-// }
+// Import takes a package and adds it to the list of packages to import
+// It returns the alias of the package if the file already imports it, else
+// the last portion of the import path, suffixed with an integer if another
+// package or a standard package already has that name
+func (p *OrmPlugin) Import(packagePath string) string {
+	imports := p.GetFileImports()
+	if alias, ok := imports.aliasOf(packagePath); ok {
+		return alias
+	}
+	name := strings.NewReplacer(".", "_", "-", "_").Replace(path.Base(packagePath))
+	alias := name
+	for i := 1; imports.taken(alias); i++ {
+		alias = fmt.Sprintf("%s%d", name, i)
+	}
+	imports.packages[alias] = &pkgImport{packagePath: packagePath, alias: alias}
+	return alias
+}
 
-// // UsingGoImports should be used with basic packages like "time", or "context"
-// func (p *OrmPlugin) UsingGoImports(pkgNames ...string) {
-// 	p.GetFileImports().stdImports = append(p.GetFileImports().stdImports, pkgNames...)
-// }
+// importAs imports a package under the name protoc-gen-star qualifies its
+// types with, the message types of other packages are named that way
+func (p *OrmPlugin) importAs(alias string, packagePath string) {
+	imports := p.GetFileImports()
+	if existing, ok := imports.aliasOf(packagePath); ok && existing != alias {
+		p.Failf("package %s is imported as both %s and %s in %s", packagePath, existing, alias, p.currentFile.Name())
+	}
+	if pkg, ok := imports.packages[alias]; ok && pkg.packagePath != packagePath {
+		p.Failf("package %s and %s are both imported as %s in %s", pkg.packagePath, packagePath, alias, p.currentFile.Name())
+	}
+	imports.packages[alias] = &pkgImport{packagePath: packagePath, alias: alias}
+}
 
-// type fileImports struct {
-// 	wktPkgName      string
-// 	typesToRegister []string
-// 	stdImports      []string
-// 	packages        map[string]*pkgImport
-// }
+// reserveImports imports the packages of the types protoc-gen-star names in a
+// file before the plugin imports its own packages, so the plugin picks other
+// names than theirs. The unused packages are removed from the generated file.
+func (p *OrmPlugin) reserveImports(file pgs.File) {
+	var entities []pgs.Entity
+	for _, msg := range file.AllMessages() {
+		for _, field := range msg.Fields() {
+			entities = append(entities, fieldEntities(field.Type())...)
+		}
+	}
+	for _, service := range file.Services() {
+		for _, method := range service.Methods() {
+			entities = append(entities, method.Input(), method.Output())
+		}
+	}
+	for _, entity := range entities {
+		if importPath := p.ctx.ImportPath(entity); importPath != p.ctx.ImportPath(file) {
+			p.importAs(p.ctx.PackageName(entity).String(), importPath.String())
+		}
+	}
+}
 
-// func newFileImports() *fileImports {
-// 	return &fileImports{packages: make(map[string]*pkgImport)}
-// }
+// fieldEntities returns the messages and enums a field type refers to
+func fieldEntities(fieldType pgs.FieldType) []pgs.Entity {
+	var entities []pgs.Entity
+	switch {
+	case fieldType.IsEmbed():
+		entities = append(entities, fieldType.Embed())
+	case fieldType.IsEnum():
+		entities = append(entities, fieldType.Enum())
+	}
+	for _, elem := range []pgs.FieldTypeElem{fieldType.Key(), fieldType.Element()} {
+		switch {
+		case elem == nil:
+		case elem.IsEmbed():
+			entities = append(entities, elem.Embed())
+		case elem.IsEnum():
+			entities = append(entities, elem.Enum())
+		}
+	}
+	return entities
+}
 
-// func (p *OrmPlugin) GetFileImports() *fileImports {
-// 	return p.fileImports[p.currentFile]
-// }
+// UsingGoImports should be used with basic packages like "time", or "context"
+func (p *OrmPlugin) UsingGoImports(pkgNames ...string) {
+	imports := p.GetFileImports()
+	for _, name := range pkgNames {
+		if !imports.usesStd(name) {
+			imports.stdImports = append(imports.stdImports, name)
+		}
+	}
+}
 
-// // GenerateImports writes out required imports for the generated files
-// func (p *OrmPlugin) GenerateImports(file *generator.FileDescriptor) {
-// 	imports := p.fileImports[file]
-// 	for _, typeName := range imports.typesToRegister {
-// 		p.RecordTypeUse(typeName)
-// 	}
-// 	githubImports := imports.packages
-// 	sort.Strings(imports.stdImports)
-// 	for _, dep := range imports.stdImports {
-// 		p.PrintImport(dep, dep)
-// 	}
-// 	p.P()
-// 	aliases := []string{}
-// 	for a := range githubImports {
-// 		aliases = append(aliases, a)
-// 	}
-// 	sort.Strings(aliases)
-// 	for _, a := range aliases {
-// 		p.PrintImport(a, githubImports[a].packagePath)
-// 	}
-// 	p.P()
-// }
+// qualifier imports a package in the current file and returns the name its
+// types are qualified with, standard packages are imported unaliased
+func (p *OrmPlugin) qualifier(packagePath string) string {
+	if isStdImport(packagePath) {
+		p.UsingGoImports(packagePath)
+		return path.Base(packagePath)
+	}
+	return p.Import(packagePath)
+}
+
+// isStdImport tells if the package belongs to the standard library, whose
+// first path element has no dot unlike domain names
+func isStdImport(packagePath string) bool {
+	return !strings.Contains(strings.Split(packagePath, "/")[0], ".")
+}
+
+type fileImports struct {
+	stdImports []string
+	packages   map[string]*pkgImport
+}
+
+func newFileImports() *fileImports {
+	return &fileImports{packages: make(map[string]*pkgImport)}
+}
+
+// stdNames are the names of the standard packages the generated code uses,
+// which are imported unaliased
+var stdNames = map[string]bool{"context": true, "fmt": true, "json": true, "strings": true, "time": true}
+
+// aliasOf returns the alias a package is imported with
+func (f *fileImports) aliasOf(packagePath string) (string, bool) {
+	for alias, pkg := range f.packages {
+		if pkg.packagePath == packagePath {
+			return alias, true
+		}
+	}
+	return "", false
+}
+
+// taken tells if a name is already used by an imported package
+func (f *fileImports) taken(name string) bool {
+	_, ok := f.packages[name]
+	return ok || stdNames[name]
+}
+
+func (f *fileImports) usesStd(pkgName string) bool {
+	for _, name := range f.stdImports {
+		if name == pkgName {
+			return true
+		}
+	}
+	return false
+}
+
+// GetFileImports returns the imports of the current file
+func (p *OrmPlugin) GetFileImports() *fileImports {
+	imports, ok := p.fileImports[p.currentFile]
+	if !ok {
+		imports = newFileImports()
+		p.fileImports[p.currentFile] = imports
+	}
+	return imports
+}
+
+// GenerateImports returns the import specs of the generated file, the
+// standard library packages first
+func (p *OrmPlugin) GenerateImports(file pgs.File) string {
+	imports := p.fileImports[file]
+	if imports == nil {
+		return ""
+	}
+	var b strings.Builder
+	stdImports := append([]string{}, imports.stdImports...)
+	sort.Strings(stdImports)
+	for _, dep := range stdImports {
+		fmt.Fprintf(&b, "%q\n", dep)
+	}
+	b.WriteString("\n")
+	aliases := []string{}
+	for a := range imports.packages {
+		aliases = append(aliases, a)
+	}
+	sort.Strings(aliases)
+	for _, a := range aliases {
+		fmt.Fprintf(&b, "%s %q\n", a, imports.packages[a].packagePath)
+	}
+	return b.String()
+}
@@ -0,0 +1,50 @@
+package plugin
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestImport(t *testing.T) {
+	p := newTestPlugin()
+	// a type of another proto package named by protoc-gen-star
+	p.importAs("structpb", structpbImport)
+	cases := []struct {
+		packagePath string
+		alias       string
+	}{
+		{gormImport, "gorm"},
+		{tgormImport, "gorm1"},
+		{structpbImport, "structpb"},
+		{uuidImport, "go_uuid"},
+		{gormImport, "gorm"},
+		{tgormImport, "gorm1"},
+	}
+
+	for _, c := range cases {
+		if alias := p.Import(c.packagePath); alias != c.alias {
+			t.Errorf("Expected %s to be imported as %s, got %s", c.packagePath, c.alias, alias)
+		}
+	}
+	p.importAs("fieldmaskpb", fieldmaskImport)
+	if alias := p.Import(fieldmaskImport); alias != "fieldmaskpb" {
+		t.Errorf("Expected %s to be imported as fieldmaskpb, got %s", fieldmaskImport, alias)
+	}
+	p.UsingGoImports(stdStringsImport)
+	if alias := p.Import("example.com/strings"); alias != "strings1" {
+		t.Errorf("Expected a package named like a standard package to be renamed, got %s", alias)
+	}
+
+	imported := map[string]bool{}
+	for _, line := range strings.Split(p.GenerateImports(nil), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		importPath := fields[len(fields)-1]
+		if imported[importPath] {
+			t.Errorf("Expected %s to be imported once, got:\n%s", importPath, p.GenerateImports(nil))
+		}
+		imported[importPath] = true
+	}
+}
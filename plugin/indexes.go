@@ -0,0 +1,88 @@
+package plugin
+
+import (
+	"fmt"
+	"strings"
+
+	gorm "github.com/TheSDTM/protoc-gen-gorm/options"
+	"github.com/gogo/protobuf/protoc-gen-gogo/generator"
+	pgs "github.com/lyft/protoc-gen-star"
+	"google.golang.org/protobuf/proto"
+)
+
+// parseIndexes resolves the indexes of the message options of an ormable type
+// and gives their settings to the index tags of the fields they cover. GORM
+// merges the tags of an index by name, in the order of their priority.
+func (p *OrmPlugin) parseIndexes(msg pgs.Message) {
+	ormable := p.getOrmable(msg)
+	for _, definition := range getMessageOptions(msg).GetIndexes() {
+		if len(definition.GetColumns()) == 0 {
+			p.Failf("an index of %s has no columns", msg.FullyQualifiedName())
+		}
+		index := proto.Clone(definition).(*gorm.IndexDefinition)
+		var fields []*Field
+		var columns []string
+		for _, column := range index.GetColumns() {
+			field := p.indexField(ormable, column.GetField())
+			fields = append(fields, field)
+			columns = append(columns, columnName(generator.CamelCase(column.GetField()), field))
+			switch strings.ToUpper(column.GetSort()) {
+			case "", "ASC", "DESC":
+			default:
+				p.Failf("the sort order of the column %s of an index of %s is not ASC or DESC", column.GetField(), ormable.Name)
+			}
+		}
+		for _, include := range index.GetInclude() {
+			p.indexField(ormable, include)
+		}
+		if index.Name == nil {
+			index.Name = proto.String(fmt.Sprintf("idx_%s_%s", unqualifiedTable(p.tableName(msg)), strings.Join(columns, "_")))
+		}
+		for _, setting := range []string{index.GetWhere(), index.GetType(), index.GetClass()} {
+			if strings.ContainsAny(setting, ";`") {
+				p.Failf("the settings of the index %s of %s cannot contain ; or `", index.GetName(), ormable.Name)
+			}
+		}
+		for i, field := range fields {
+			field.Indexes = append(field.Indexes, indexTag(index, i))
+		}
+		ormable.Indexes = append(ormable.Indexes, index)
+	}
+}
+
+// indexField returns the field of an ormable type named by an index, by its
+// proto or its Go name
+func (p *OrmPlugin) indexField(ormable *OrmableType, name string) *Field {
+	field, ok := ormable.Fields[generator.CamelCase(name)]
+	if !ok || strings.HasSuffix(field.Type, "ORM") || field.GetTag().GetIgnore() {
+		p.Failf("an index of %s covers %s which is not a column of it", ormable.Name, name)
+	}
+	return field
+}
+
+// indexTag returns the gorm tag setting of the column i of an index
+func indexTag(index *gorm.IndexDefinition, i int) string {
+	key := "index"
+	if index.GetUnique() {
+		key = "uniqueIndex"
+	}
+	settings := []string{index.GetName(), fmt.Sprintf("priority:%d", i+1)}
+	if sort := index.GetColumns()[i].GetSort(); sort != "" {
+		settings = append(settings, "sort:"+strings.ToLower(sort))
+	}
+	if index.GetType() != "" {
+		settings = append(settings, "type:"+index.GetType())
+	}
+	if index.GetClass() != "" {
+		settings = append(settings, "class:"+index.GetClass())
+	}
+	if index.GetWhere() != "" {
+		settings = append(settings, "where:"+index.GetWhere())
+	}
+	for i, setting := range settings {
+		// GORM unescapes the commas of a setting, the tag its quotes
+		setting = strings.Replace(setting, ",", `\,`, -1)
+		settings[i] = strings.Replace(strings.Replace(setting, `\`, `\\`, -1), `"`, `\"`, -1)
+	}
+	return key + ":" + strings.Join(settings, ",")
+}
@@ -0,0 +1,120 @@
+package plugin
+
+import (
+	"github.com/gogo/protobuf/protoc-gen-gogo/generator"
+	pgs "github.com/lyft/protoc-gen-star"
+)
+
+// mapMapping returns the ORM representation of a map field: the string map
+// type of the engine for map<string, string>, a JSON document otherwise.
+// Maps with bool keys cannot be encoded as JSON objects and are not supported.
+func (p *OrmPlugin) mapMapping(field pgs.Field) (typeMapping, bool) {
+	if field.Type().Key().ProtoType() == pgs.BoolT {
+		p.warning(`map field %s has bool keys which are not supported, it is dropped`, field.FullyQualifiedName())
+		return typeMapping{}, false
+	}
+	if p.isStringMap(field) {
+		return *p.dialect.stringMap, true
+	}
+	return p.dialect.document, true
+}
+
+// isStringMap tells if the field is stored in the string map type of the engine
+func (p *OrmPlugin) isStringMap(field pgs.Field) bool {
+	return p.dialect.stringMap != nil && field.Type().Key().ProtoType() == pgs.StringT &&
+		field.Type().Element().ProtoType() == pgs.StringT
+}
+
+// generateMapConversion outputs the conversion of a map field. Scalar values
+// are encoded with encoding/json, enums as their ORM value and messages with
+// protojson.
+func (p *OrmPlugin) generateMapConversion(field pgs.Field, toORM bool) {
+	fieldName := generator.CamelCase(string(field.Name()))
+	fieldType := p.ctx.Type(field).String()
+	keyType := p.ctx.Type(field).Key().String()
+	if p.isStringMap(field) {
+		p.generateStringMapConversion(fieldName, toORM)
+		return
+	}
+	p.UsingGoImports(encodingJsonImport)
+	el := field.Type().Element()
+	p.P(`if m.`, fieldName, ` != nil {`)
+	switch {
+	case el.IsEnum() && toORM:
+		p.P(`converted := make(map[`, keyType, `]`, p.enumFormat(field).ormType(), `, len(m.`, fieldName, `))`)
+		p.P(`for k, v := range m.`, fieldName, ` {`)
+		p.generateEnumConversion(field, "v", "converted[k]", toORM)
+		p.P(`}`)
+		p.generateDocumentEncoding(fieldName, "converted")
+	case el.IsEnum():
+		p.P(`converted := map[`, keyType, `]`, p.enumFormat(field).ormType(), `{}`)
+		p.generateDocumentDecoding(fieldName, "&converted")
+		p.P(`to.`, fieldName, ` = make(`, fieldType, `, len(converted))`)
+		p.P(`for k, v := range converted {`)
+		p.generateEnumConversion(field, "v", "to."+fieldName+"[k]", toORM)
+		p.P(`}`)
+	case el.IsEmbed() && toORM:
+		p.P(`converted := make(map[`, keyType, `]json.RawMessage, len(m.`, fieldName, `))`)
+		p.P(`for k, v := range m.`, fieldName, ` {`)
+		p.P(`if converted[k], err = `, p.Import(protojsonImport), `.Marshal(v); err != nil {`)
+		p.P(`return to, err`)
+		p.P(`}`)
+		p.P(`}`)
+		p.generateDocumentEncoding(fieldName, "converted")
+	case el.IsEmbed():
+		msgType := p.messageTypeName(el.Embed())
+		p.P(`converted := map[`, keyType, `]json.RawMessage{}`)
+		p.generateDocumentDecoding(fieldName, "&converted")
+		p.P(`to.`, fieldName, ` = make(`, fieldType, `, len(converted))`)
+		p.P(`for k, v := range converted {`)
+		p.P(`to.`, fieldName, `[k] = &`, msgType, `{}`)
+		p.P(`if err = `, p.Import(protojsonImport), `.Unmarshal(v, to.`, fieldName, `[k]); err != nil {`)
+		p.P(`return to, err`)
+		p.P(`}`)
+		p.P(`}`)
+	case toORM:
+		p.generateDocumentEncoding(fieldName, "m."+fieldName)
+	default:
+		p.generateDocumentDecoding(fieldName, "&to."+fieldName)
+	}
+	p.P(`}`)
+}
+
+// generateStringMapConversion outputs the conversion between a
+// map[string]string and the map[string]*string of the engine
+func (p *OrmPlugin) generateStringMapConversion(fieldName string, toORM bool) {
+	p.P(`if m.`, fieldName, ` != nil {`)
+	if toORM {
+		p.P(`to.`, fieldName, ` = make(`, p.mappedType(*p.dialect.stringMap), `, len(m.`, fieldName, `))`)
+		p.P(`for k, v := range m.`, fieldName, ` {`)
+		p.P(`v := v`)
+		p.P(`to.`, fieldName, `[k] = &v`)
+		p.P(`}`)
+	} else {
+		p.P(`to.`, fieldName, ` = make(map[string]string, len(m.`, fieldName, `))`)
+		p.P(`for k, v := range m.`, fieldName, ` {`)
+		p.P(`if v != nil {`)
+		p.P(`to.`, fieldName, `[k] = *v`)
+		p.P(`}`)
+		p.P(`}`)
+	}
+	p.P(`}`)
+}
+
+// generateDocumentEncoding outputs the JSON encoding of value into the
+// document field of the ORM object
+func (p *OrmPlugin) generateDocumentEncoding(fieldName string, value string) {
+	p.P(`data, err := json.Marshal(`, value, `)`)
+	p.P(`if err != nil {`)
+	p.P(`return to, err`)
+	p.P(`}`)
+	p.P(`to.`, fieldName, ` = data`)
+}
+
+// generateDocumentDecoding outputs the JSON decoding of the document field of
+// the ORM object into dest
+func (p *OrmPlugin) generateDocumentDecoding(fieldName string, dest string) {
+	p.P(`if err = json.Unmarshal(m.`, fieldName, `, `, dest, `); err != nil {`)
+	p.P(`return to, err`)
+	p.P(`}`)
+}
@@ -0,0 +1,194 @@
+package plugin
+
+import (
+	"strings"
+
+	gorm "github.com/TheSDTM/protoc-gen-gorm/options"
+	"github.com/gogo/protobuf/protoc-gen-gogo/generator"
+	pgs "github.com/lyft/protoc-gen-star"
+)
+
+// inRealOneOf tells if the field is a member of a oneof declared in the proto,
+// as opposed to the synthetic oneof of a proto3 optional field
+func inRealOneOf(field pgs.Field) bool {
+	return field.InOneOf() && !isProto3Optional(field)
+}
+
+// oneofCaseName returns the name of the ORM field recording which member of
+// the oneof is set, by its proto name
+func oneofCaseName(oneof pgs.OneOf) string {
+	return generator.CamelCase(string(oneof.Name())) + "Case"
+}
+
+// oneofMemberType returns the nullable ORM type of a oneof member given the
+// type it would have outside of the oneof
+func (p *OrmPlugin) oneofMemberType(field pgs.Field, fieldType string) string {
+	if p.isSerialized(field) || wktName(field) != "" || fieldType == "[]byte" {
+		return fieldType
+	}
+	if !field.Type().IsEmbed() {
+		return "*" + fieldType
+	}
+	switch coreType := p.coreType(field); {
+	case coreType == protoTypeUUID:
+		return "*" + fieldType
+	case coreType == protoTypeTimestamp, coreType == protoTypeUUIDValue:
+		return fieldType
+	case coreType != "Struct" && wellKnownTypes[coreType] != "":
+		return fieldType
+	}
+	p.oneofMemberUnsupported(field)
+	return ""
+}
+
+// oneofMemberUnsupported fails on a oneof member of a message type which has
+// no column of its own
+func (p *OrmPlugin) oneofMemberUnsupported(field pgs.Field) {
+	p.Failf("oneof member %s of type %s cannot be stored, serialize it or make its type ormable", field.FullyQualifiedName(), p.ctx.Type(field))
+}
+
+// addOneofCases adds the discriminator field of every oneof of the message
+func (p *OrmPlugin) addOneofCases(msg pgs.Message, ormable *OrmableType) {
+	for _, oneof := range msg.OneOfs() {
+		if len(oneof.Fields()) == 0 || !inRealOneOf(oneof.Fields()[0]) {
+			continue
+		}
+		caseName := oneofCaseName(oneof)
+		if _, ok := ormable.Fields[caseName]; ok {
+			p.Fail("Cannot add the", caseName, "field of the", string(oneof.Name()), "oneof into", ormable.Name, "as it aready exists there.")
+		}
+		ormable.Fields[caseName] = &Field{Type: "string", GormFieldOptions: &gorm.GormFieldOptions{}}
+		ormable.FieldsOrder = append(ormable.FieldsOrder, caseName)
+	}
+}
+
+// oneofMembers returns the members of a oneof which are stored in the ORM object
+func oneofMembers(ormable *OrmableType, oneof pgs.OneOf) []pgs.Field {
+	var members []pgs.Field
+	for _, field := range oneof.Fields() {
+		if _, ok := ormable.Fields[generator.CamelCase(string(field.Name()))]; ok && !getFieldOptions(field).GetDrop() {
+			members = append(members, field)
+		}
+	}
+	return members
+}
+
+// generateOneofConversion outputs the conversion of a whole oneof: the set
+// member is converted to its nullable ORM field and recorded in the case field
+func (p *OrmPlugin) generateOneofConversion(oneof pgs.OneOf, members []pgs.Field, toORM bool) {
+	oneofName := generator.CamelCase(string(oneof.Name()))
+	caseName := oneofCaseName(oneof)
+	if toORM {
+		p.P(`switch member := m.`, oneofName, `.(type) {`)
+	} else {
+		p.P(`switch m.`, caseName, ` {`)
+	}
+	for _, field := range members {
+		fieldName := generator.CamelCase(string(field.Name()))
+		if toORM {
+			p.P(`case *`, p.ctx.OneofOption(field).String(), `:`)
+			p.P(`to.`, caseName, ` = "`, string(field.Name()), `"`)
+			p.generateOneofMemberToORM(field, "member."+fieldName, "to."+fieldName)
+		} else {
+			p.P(`case "`, string(field.Name()), `":`)
+			p.P(`member := &`, p.ctx.OneofOption(field).String(), `{}`)
+			p.generateOneofMemberToPB(field, "m."+fieldName, "member."+fieldName)
+			p.P(`to.`, oneofName, ` = member`)
+		}
+	}
+	p.P(`}`)
+}
+
+func (p *OrmPlugin) generateOneofMemberToORM(field pgs.Field, src string, dst string) {
+	if p.isSerialized(field) {
+		p.generateSerializedConversion(field, src, dst, true)
+		return
+	}
+	if wkt := wktName(field); wkt != "" {
+		p.generateWKTConversion(field, wkt, src, dst, true)
+		return
+	}
+	if field.Type().IsEnum() {
+		p.P(`var v `, p.enumFormat(field).ormType())
+		p.generateEnumConversion(field, src, "v", true)
+		p.P(dst, ` = &v`)
+		return
+	}
+	if !field.Type().IsEmbed() {
+		if field.Type().ProtoType() == pgs.BytesT {
+			p.P(dst, ` = `, src)
+		} else {
+			p.P(`v := `, src)
+			p.P(dst, ` = &v`)
+		}
+		return
+	}
+	p.P(`if `, src, ` != nil {`)
+	switch coreType := p.coreType(field); {
+	case coreType == protoTypeTimestamp:
+		p.P(`t, err := `, p.Import(ptypesImport), `.Timestamp(`, src, `)`)
+		p.P(`if err != nil {`)
+		p.P(`return to, err`)
+		p.P(`}`)
+		p.P(dst, ` = &t`)
+	case coreType == protoTypeUUID, coreType == protoTypeUUIDValue:
+		p.P(`u, err := `, p.Import(uuidImport), `.FromString(`, src, `.Value)`)
+		p.P(`if err != nil {`)
+		p.P(`return to, err`)
+		p.P(`}`)
+		p.P(dst, ` = &u`)
+	case p.isOrmableField(field):
+		p.P(`temp, err := `, src, `.ToORM(ctx)`)
+		p.P(`if err != nil {`)
+		p.P(`return to, err`)
+		p.P(`}`)
+		p.P(dst, ` = &temp`)
+	default: // wrapper types
+		p.P(`v := `, src, `.Value`)
+		p.P(dst, ` = &v`)
+	}
+	p.P(`}`)
+}
+
+func (p *OrmPlugin) generateOneofMemberToPB(field pgs.Field, src string, dst string) {
+	if p.isSerialized(field) {
+		p.generateSerializedConversion(field, src, dst, false)
+		return
+	}
+	if wkt := wktName(field); wkt != "" {
+		p.generateWKTConversion(field, wkt, src, dst, false)
+		return
+	}
+	if !field.Type().IsEmbed() && field.Type().ProtoType() == pgs.BytesT {
+		p.P(dst, ` = `, src)
+		return
+	}
+	p.P(`if `, src, ` != nil {`)
+	switch coreType := p.coreType(field); {
+	case field.Type().IsEnum():
+		p.generateEnumConversion(field, "*"+src, dst, false)
+	case !field.Type().IsEmbed():
+		p.P(dst, ` = *`, src)
+	case coreType == protoTypeTimestamp:
+		p.P(`if `, dst, `, err = `, p.Import(ptypesImport), `.TimestampProto(*`, src, `); err != nil {`)
+		p.P(`return to, err`)
+		p.P(`}`)
+	case coreType == protoTypeUUID, coreType == protoTypeUUIDValue:
+		p.P(dst, ` = &`, p.Import(gtypesImport), `.`, coreType, `{Value: `, src, `.String()}`)
+	case p.isOrmableField(field):
+		p.P(`temp, err := `, src, `.ToPB(ctx)`)
+		p.P(`if err != nil {`)
+		p.P(`return to, err`)
+		p.P(`}`)
+		p.P(dst, ` = &temp`)
+	default: // wrapper types
+		p.P(dst, ` = &`, p.messageTypeName(field.Type().Embed()), `{Value: *`, src, `}`)
+	}
+	p.P(`}`)
+}
+
+// coreType returns the unqualified Go type of a message field
+func (p *OrmPlugin) coreType(field pgs.Field) string {
+	parts := strings.Split(p.ctx.Type(field).String(), ".")
+	return parts[len(parts)-1]
+}
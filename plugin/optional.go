@@ -0,0 +1,66 @@
+package plugin
+
+import (
+	"io"
+
+	"github.com/gogo/protobuf/protoc-gen-gogo/generator"
+	pgs "github.com/lyft/protoc-gen-star"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+// isProto3Optional tells if the field is declared optional in a proto3 file,
+// so that its presence is tracked through a synthetic oneof
+func isProto3Optional(field pgs.Field) bool {
+	return field.Descriptor().GetProto3Optional()
+}
+
+// isOptionalScalar tells if the field is a proto3 optional scalar or enum,
+// which is a pointer in the PB object and a nullable column in the ORM object.
+// Optional bytes keep their nil slice as NULL.
+func isOptionalScalar(field pgs.Field) bool {
+	return isProto3Optional(field) && !field.Type().IsEmbed() && field.Type().ProtoType() != pgs.BytesT
+}
+
+// generateOptionalConversion outputs the conversion of an optional scalar
+// field, copying the value so that both objects don't share it
+func (p *OrmPlugin) generateOptionalConversion(field pgs.Field, toORM bool) {
+	fieldName := generator.CamelCase(string(field.Name()))
+	p.P(`if m.`, fieldName, ` != nil {`)
+	switch {
+	case field.Type().IsEnum() && toORM:
+		p.P(`var v `, p.enumFormat(field).ormType())
+		p.generateEnumConversion(field, "*m."+fieldName, "v", toORM)
+	case field.Type().IsEnum():
+		p.P(`var v `, p.enumGoType(field))
+		p.generateEnumConversion(field, "*m."+fieldName, "v", toORM)
+	default:
+		p.P(`v := *m.`, fieldName)
+	}
+	p.P(`to.`, fieldName, ` = &v`)
+	p.P(`}`)
+}
+
+// FeatureWriter tells protoc that the plugin supports proto3 optional fields,
+// which protoc-gen-star cannot declare. It expects the serialized
+// CodeGeneratorResponse in a single Write, as protoc-gen-star outputs it.
+type FeatureWriter struct {
+	io.Writer
+}
+
+// Write adds the supported features to the response and writes it
+func (w FeatureWriter) Write(data []byte) (int, error) {
+	resp := &pluginpb.CodeGeneratorResponse{}
+	if err := proto.Unmarshal(data, resp); err != nil {
+		return 0, err
+	}
+	resp.SupportedFeatures = proto.Uint64(uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL))
+	out, err := proto.Marshal(resp)
+	if err != nil {
+		return 0, err
+	}
+	if _, err = w.Writer.Write(out); err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
@@ -1,6 +1,7 @@
 package plugin
 
 import (
+	"bytes"
 	"fmt"
 	"sort"
 	"strings"
@@ -33,12 +34,6 @@ const (
 	protoTimeOnly      = "TimeOnly"
 )
 
-// DB Engine Enum
-const (
-	ENGINE_UNSET = iota
-	ENGINE_POSTGRES
-)
-
 var wellKnownTypes = map[string]string{
 	"StringValue": "*string",
 	"DoubleValue": "*float64",
@@ -49,7 +44,6 @@ var wellKnownTypes = map[string]string{
 	"UInt64Value": "*uint64",
 	"BoolValue":   "*bool",
 	"Struct":      "[]byte",
-	//  "BytesValue" : "*[]byte",
 }
 
 var builtinTypes = map[string]struct{}{
@@ -73,6 +67,8 @@ type OrmableType struct {
 	File        pgs.File
 	Fields      map[string]*Field
 	FieldsOrder []string
+	// Indexes are the indexes of the message options, named
+	Indexes []*gorm.IndexDefinition
 }
 
 type Field struct {
@@ -81,6 +77,12 @@ type Field struct {
 	Package      string
 	*gorm.GormFieldOptions
 	ParentOriginName string
+	// Indexes are the index tag settings given to the field by the indexes
+	// of its message options
+	Indexes []string
+	// SoftDelete is the mode of the soft delete mark of the rows, zero for
+	// the other fields
+	SoftDelete gorm.SoftDeleteMode
 }
 
 func NewOrmableType(oname string, file pgs.File) *OrmableType {
@@ -99,19 +101,24 @@ type OrmPlugin struct {
 	ctx pgsgo.Context
 	tpl *template.Template
 
-	wktPkgName        string
+	paramEngine       int
+	ddlEngine         int
 	dbEngine          int
+	dialect           *dialect
 	stringEnums       bool
 	gateway           bool
 	ormableTypes      map[string]*OrmableType
+	targets           map[pgs.File]bool
+	files             []pgs.File
 	EmptyFiles        []string
 	currentPackage    string
 	currentFile       pgs.File
 	currentFileName   string
 	currentFileBuffer []string
-	fileImports       map[string]string
-	messages          map[string]struct{}
+	fileImports       map[pgs.File]*fileImports
+	messages          map[string]pgs.Message
 	suppressWarn      bool
+	txnMiddleware     bool
 }
 
 // Name identifies the plugin
@@ -125,14 +132,18 @@ func (p *OrmPlugin) InitContext(c pgs.BuildContext) {
 	p.ModuleBase.InitContext(c)
 	p.ctx = pgsgo.InitContext(c.Parameters())
 
-	p.fileImports = make(map[string]string)
-	p.messages = make(map[string]struct{})
+	p.fileImports = make(map[pgs.File]*fileImports)
+	p.messages = make(map[string]pgs.Message)
 	p.ormableTypes = map[string]*OrmableType{}
-	if strings.EqualFold(p.ctx.Params()["engine"], "postgres") {
-		p.dbEngine = ENGINE_POSTGRES
-	} else {
-		p.dbEngine = ENGINE_UNSET
+	p.targets = map[pgs.File]bool{}
+	p.paramEngine = p.parseEngine(p.ctx.Params()["engine"])
+	p.ddlEngine = p.parseEngine(p.ctx.Params()["ddl"])
+	if p.paramEngine == ENGINE_UNSET {
+		// the DDL describes the columns of the Go types
+		p.paramEngine = p.ddlEngine
 	}
+	p.dbEngine = p.paramEngine
+	p.dialect = dialects[p.dbEngine]
 	if strings.EqualFold(p.ctx.Params()["enums"], "string") {
 		p.stringEnums = true
 	}
@@ -144,64 +155,107 @@ func (p *OrmPlugin) InitContext(c pgs.BuildContext) {
 	}
 }
 
-func (p *OrmPlugin) preparse(targets map[string]pgs.File) {
+// preparse registers the ormable types of every loaded package by their fully
+// qualified proto name and parses their fields. The types of other packages
+// are needed by the associations with them, but only the targets are
+// generated.
+func (p *OrmPlugin) preparse(targets map[string]pgs.File, pkgs map[string]pgs.Package) {
 	for _, t := range targets {
-		for _, msg := range t.Messages() {
+		p.targets[t] = true
+	}
+	var files []pgs.File
+	for _, pkg := range pkgs {
+		files = append(files, pkg.Files()...)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Name() < files[j].Name() })
+	p.files = files
+	for _, file := range files {
+		for _, msg := range file.AllMessages() {
 			// We don't want to bother with the MapEntry stuff
 			if msg.Descriptor().GetOptions().GetMapEntry() {
 				continue
 			}
-			typeName := p.getMsgName(msg)
-			p.messages[typeName] = struct{}{}
-
-			if opts := getMessageOptions(msg); opts != nil && opts.GetOrmable() {
-				ormable := NewOrmableType(typeName, t)
-				if _, ok := p.ormableTypes[typeName]; !ok {
-					p.ormableTypes[typeName] = ormable
-				}
+			p.messages[msg.FullyQualifiedName()] = msg
+			if declaredOrmable(msg) {
+				p.ormableTypes[msg.FullyQualifiedName()] = NewOrmableType(p.TypeName(msg), file)
 			}
 		}
-		for _, msg := range t.Messages() {
-			typeName := p.getMsgName(msg)
-			if p.isOrmable(typeName) {
+	}
+	// every type has its fields before the associations add foreign keys
+	for _, file := range files {
+		// the types of the fields are qualified with the imports of their file
+		p.setFile(file)
+		for _, msg := range file.AllMessages() {
+			if p.isOrmable(msg) {
 				p.parseBasicFields(msg)
 			}
 		}
-		for _, msg := range t.Messages() {
-			typeName := p.getMsgName(msg)
-			if p.isOrmable(typeName) {
+	}
+	for _, file := range files {
+		p.setFile(file)
+		for _, msg := range file.AllMessages() {
+			if p.isOrmable(msg) {
 				p.parseAssociations(msg)
-				o := p.getOrmable(typeName)
-				if p.hasPrimaryKey(o) {
-					_, fd := p.findPrimaryKey(o)
-					fd.ParentOriginName = o.OriginName
+				o := p.getOrmable(msg)
+				for _, pkName := range p.primaryKeys(o) {
+					o.Fields[pkName].ParentOriginName = o.OriginName
 				}
 			}
 		}
 	}
+	// the foreign keys have been added to the types of any file
+	for _, ormable := range p.ormableTypes {
+		applyColumnCase(ormable)
+	}
+	// the indexes are named after the columns and may cover foreign keys
+	for _, file := range files {
+		for _, msg := range file.AllMessages() {
+			if p.isOrmable(msg) {
+				p.parseIndexes(msg)
+			}
+		}
+	}
+}
+
+// setFile makes file the current one, its options override the engine plugin
+// parameter
+func (p *OrmPlugin) setFile(file pgs.File) {
+	p.currentFile = file
+	p.dbEngine = p.paramEngine
+	if engine := getFileOptions(file).GetEngine(); engine != "" {
+		p.dbEngine = p.parseEngine(engine)
+	}
+	p.dialect = dialects[p.dbEngine]
+	p.reserveImports(file)
 }
 
 func (p *OrmPlugin) generate(f pgs.File) {
-	if len(f.Messages()) == 0 {
+	if len(f.AllMessages()) == 0 && len(f.Services()) == 0 {
 		p.EmptyFiles = append(p.EmptyFiles, string(f.Name()))
 		return
 	}
 
 	fileName := p.ctx.OutputPath(f).SetExt(".gorm.go")
 
-	p.currentFile = f
+	p.setFile(f)
 	p.currentFileName = string(fileName)
 	p.currentFileBuffer = []string{}
+	p.UsingGoImports(stdCtxImport)
 
-	for _, msg := range f.Messages() {
-		typeName := p.getMsgName(msg)
-		if p.isOrmable(typeName) {
+	for _, msg := range f.AllMessages() {
+		if p.isOrmable(msg) {
 			p.generateOrmable(msg)
-			// p.generateTableNameFunction(msg)
+			p.generateTableNameFunction(msg)
+			p.generateUUIDReorder(msg)
 			p.generateConvertFunctions(msg)
 			p.generateHookInterfaces(msg)
+			p.generateDefaultHandlers(msg)
 		}
 	}
+	p.generateNativeEnumTypes(f)
+	for _, service := range f.Services() {
+		p.generateDefaultServer(service)
+	}
 
 	if len(p.currentFileBuffer) == 0 {
 		return
@@ -212,11 +266,7 @@ func (p *OrmPlugin) generate(f pgs.File) {
 		"package": p.ctx.PackageName,
 		"name":    p.ctx.Name,
 		"generatedImports": func() string {
-			importsRes := ""
-			for k, v := range p.fileImports {
-				importsRes += k + " \"" + v + "\"\n\t"
-			}
-			return importsRes
+			return p.GenerateImports(f)
 		},
 		"generated_body": func() string {
 			return res
@@ -225,102 +275,120 @@ func (p *OrmPlugin) generate(f pgs.File) {
 
 	p.tpl = template.Must(tpl.Parse(headerTpl))
 
-	p.AddGeneratorTemplateFile(p.currentFileName, p.tpl, f)
+	var buf bytes.Buffer
+	if err := p.tpl.Execute(&buf, f); err != nil {
+		p.Failf("cannot render %s: %s", p.currentFileName, err)
+	}
+	p.AddGeneratorFile(p.currentFileName, p.formatSource(p.currentFileName, buf.Bytes()))
 }
 
 func (p *OrmPlugin) Execute(targets map[string]pgs.File, pkgs map[string]pgs.Package) []pgs.Artifact {
-	p.preparse(targets)
+	p.preparse(targets, pkgs)
 	for _, t := range targets {
 		p.generate(t)
 	}
+	if p.ddlEngine != ENGINE_UNSET {
+		d, ok := ddlDialects[p.ddlEngine]
+		if !ok {
+			p.Failf("no DDL can be generated for the %s engine", engineName(p.ddlEngine))
+		}
+		for _, t := range targets {
+			p.generateDDL(d, t)
+		}
+		p.generateSnapshots(d, targets)
+	}
 	return p.Artifacts()
 }
 
 func (p *OrmPlugin) parseBasicFields(msg pgs.Message) {
-	typeName := p.getMsgName(msg)
-	ormable := p.getOrmable(typeName)
+	typeName := p.TypeName(msg)
+	ormable := p.getOrmable(msg)
 	ormable.Name = fmt.Sprintf("%sORM", typeName)
 	for _, field := range msg.Fields() {
 		fieldOpts := getFieldOptions(field)
 		if fieldOpts == nil {
 			fieldOpts = &gorm.GormFieldOptions{}
 		}
-		if fieldOpts.GetDrop() {
+		if fieldOpts.GetDrop() || isSoftDeleteTime(msg, field) {
 			continue
 		}
-		tag := fieldOpts.GetTag()
+		if tag := defaultTag(field); tag != nil {
+			fieldOpts.Tag = mergeTags(tag, fieldOpts.GetTag())
+		}
 		fieldName := generator.CamelCase(string(field.Name()))
 		fieldType := string(p.ctx.Type(field))
 		var typePackage string
-		if p.dbEngine == ENGINE_POSTGRES && p.IsAbleToMakePQArray(fieldType) {
-			p.fileImports["pqImport"] = "github.com/lib/pq"
-			switch fieldType {
-			case "[]bool":
-				fieldType = fmt.Sprintf("%s.BoolArray", "pqImport")
-				fieldOpts.Tag = tagWithType(tag, "bool[]")
-			case "[]float64":
-				fieldType = fmt.Sprintf("%s.Float64Array", "pqImport")
-				fieldOpts.Tag = tagWithType(tag, "float[]")
-			case "[]int64":
-				fieldType = fmt.Sprintf("%s.Int64Array", "pqImport")
-				fieldOpts.Tag = tagWithType(tag, "integer[]")
-			case "[]string":
-				fieldType = fmt.Sprintf("%s.StringArray", "pqImport")
-				fieldOpts.Tag = tagWithType(tag, "text[]")
-			default:
+		if field.Type().IsMap() {
+			mapping, ok := p.mapMapping(field)
+			if !ok {
 				continue
 			}
-		} else if (!field.Type().IsEmbed() || !p.isOrmable(fieldType)) && field.Type().IsRepeated() {
+			fieldType = p.useMapping(mapping, fieldOpts)
+			typePackage = mapping.pkg
+		} else if array, ok := p.dialect.arrays[fieldType]; ok {
+			fieldType = p.useMapping(array, fieldOpts)
+			typePackage = array.pkg
+		} else if field.Type().IsRepeated() && field.Type().Element().IsEnum() {
+			format := p.enumFormat(field)
+			array := p.dialect.arrays["[]"+format.ormType()]
+			if format.arrayColumn != "" {
+				array.column = format.arrayColumn
+			} else if format.storage == gorm.EnumStorage_NATIVE {
+				p.warning(`repeated enum field %s cannot be stored natively by this engine, it is stored as an array of strings`, field.FullyQualifiedName())
+			}
+			fieldType = p.useMapping(array, fieldOpts)
+			typePackage = array.pkg
+		} else if (!field.Type().IsEmbed() || !p.isOrmableField(field)) && field.Type().IsRepeated() {
 			// Not implemented yet
 			continue
 
 		} else if field.Type().IsEnum() {
-			fieldType = "int32"
-			if p.stringEnums {
-				fieldType = "string"
+			format := p.enumFormat(field)
+			if format.storage == gorm.EnumStorage_NATIVE && format.column == "" {
+				p.warning(`enum field %s cannot be stored natively by this engine, it is stored as a string`, field.FullyQualifiedName())
 			}
+			fieldType = p.useMapping(format.mapping(), fieldOpts)
+		} else if p.isSerialized(field) {
+			mapping := p.serializedMapping(field)
+			fieldType = p.useMapping(mapping, fieldOpts)
+			typePackage = mapping.pkg
 		} else if field.Type().IsEmbed() {
 			//Check for WKTs or fields of nonormable types
 			parts := strings.Split(fieldType, ".")
 			rawType := parts[len(parts)-1]
-			if v, exists := wellKnownTypes[rawType]; exists {
+			if wkt := wktName(field); wkt != "" {
+				mapping := p.wktMapping(wkt)
+				fieldType = p.useMapping(mapping, fieldOpts)
+				typePackage = mapping.pkg
+			} else if v, exists := wellKnownTypes[rawType]; exists {
 				if rawType != "Struct" {
 					// TODO perfilov
 					// p.typesToRegister = append(p.typesToRegister, field.GetTypeName())
-					p.wktPkgName = strings.Trim(parts[0], "*")
 					fieldType = v
 					typePackage = wktImport
-					p.fileImports["wrappers"] = "github.com/golang/protobuf/ptypes/wrappers"
 				} else {
-					p.fileImports["_struct"] = "google.golang.org/protobuf/types/known/structpb"
-					p.fileImports["json"] = "encoding/json"
-					fieldType = v
+					fieldType = p.useMapping(p.dialect.structType, fieldOpts)
+					typePackage = p.dialect.structType.pkg
 				}
 			} else if rawType == protoTypeUUID {
-				fieldType = fmt.Sprintf("%s.UUID", "uuidImport")
+				fieldType = fmt.Sprintf("%s.UUID", p.Import(uuidImport))
 				typePackage = uuidImport
-				if p.dbEngine == ENGINE_POSTGRES {
-					fieldOpts.Tag = tagWithType(tag, "uuid")
-				}
+				useColumn(p.dialect.uuidColumn, fieldOpts)
 			} else if rawType == protoTypeUUIDValue {
-				fieldType = fmt.Sprintf("*%s.UUID", "uuidImport")
+				fieldType = fmt.Sprintf("*%s.UUID", p.Import(uuidImport))
 				typePackage = uuidImport
-				if p.dbEngine == ENGINE_POSTGRES {
-					fieldOpts.Tag = tagWithType(tag, "uuid")
-				}
+				useColumn(p.dialect.uuidColumn, fieldOpts)
 			} else if rawType == protoTypeTimestamp {
-				p.fileImports["stdTimeImport"] = "time"
+				p.UsingGoImports(stdTimeImport)
 				typePackage = stdTimeImport
-				fieldType = fmt.Sprintf("*%s.Time", "stdTimeImport")
+				fieldType = "*time.Time"
+				useColumn(p.dialect.timestampColumn, fieldOpts)
 			} else if rawType == protoTypeJSON {
-				if p.dbEngine == ENGINE_POSTGRES {
-					fieldType = fmt.Sprintf("*%s.Jsonb", "gormpqImport")
-					typePackage = gormpqImport
-					fieldOpts.Tag = tagWithType(tag, "jsonb")
-				} else {
-					// Potential TODO: add types we want to use in other/default DB engine
+				if p.dialect.json == nil {
 					continue
 				}
+				fieldType = "*" + p.useMapping(p.dialect.json.typeMapping, fieldOpts)
+				typePackage = p.dialect.json.pkg
 			} else if rawType == protoTypeResource {
 				tag := getFieldOptions(field).GetTag()
 				ttype := tag.GetType()
@@ -347,30 +415,38 @@ func (p *OrmPlugin) parseBasicFields(msg pgs.Message) {
 					fieldType = strings.TrimPrefix(fieldType, "*")
 				}
 			} else if rawType == protoTypeInet {
-				fieldType = fmt.Sprintf("*%s.Inet", "gtypesImport")
+				fieldType = fmt.Sprintf("*%s.Inet", p.Import(gtypesImport))
 				typePackage = gtypesImport
-				if p.dbEngine == ENGINE_POSTGRES {
-					fieldOpts.Tag = tagWithType(tag, "inet")
-				} else {
-					fieldOpts.Tag = tagWithType(tag, "varchar(48)")
-				}
+				useColumn(p.dialect.inetColumn, fieldOpts)
 			} else if rawType == protoTimeOnly {
 				fieldType = "string"
-				fieldOpts.Tag = tagWithType(tag, "time")
+				useColumn(p.dialect.timeOnlyColumn, fieldOpts)
 			} else {
+				if inRealOneOf(field) && !p.isOrmableField(field) {
+					p.oneofMemberUnsupported(field)
+				}
 				continue
 			}
 		}
+		if isOptionalScalar(field) {
+			fieldType = "*" + fieldType
+		}
+		if inRealOneOf(field) {
+			fieldType = p.oneofMemberType(field, fieldType)
+		}
 		f := &Field{Type: fieldType, Package: typePackage, GormFieldOptions: fieldOpts}
 		if tname := getFieldOptions(field).GetReferenceOf(); tname != "" {
-			if _, ok := p.messages[tname]; !ok {
+			ref := p.lookupMessage(msg, tname)
+			if ref == nil {
 				p.Fail("unknown message type in refers_to: ", tname, " in field: ", fieldName, " of type: ", typeName)
 			}
-			f.ParentOriginName = tname
+			f.ParentOriginName = p.messageTypeName(ref)
 		}
 		ormable.Fields[fieldName] = f
 		ormable.FieldsOrder = append(ormable.FieldsOrder, fieldName)
 	}
+	p.addOneofCases(msg, ormable)
+	p.parseSoftDelete(msg)
 	for _, field := range getMessageOptions(msg).GetInclude() {
 		fieldName := generator.CamelCase(field.GetName())
 		if _, ok := ormable.Fields[fieldName]; !ok {
@@ -379,6 +455,7 @@ func (p *OrmPlugin) parseBasicFields(msg pgs.Message) {
 			p.Fail("Cannot include", fieldName, "field into", ormable.Name, "as it aready exists there.")
 		}
 	}
+	p.setKeyDefault(ormable)
 }
 
 func tagWithType(tag *gorm.GormTag, typename string) *gorm.GormTag {
@@ -398,8 +475,7 @@ func (p *OrmPlugin) addIncludedField(ormable *OrmableType, field *gorm.ExtraFiel
 	var typePackage string
 	// Handle types with a package defined
 	if field.GetPackage() != "" {
-		alias := field.GetPackage()
-		rawType = fmt.Sprintf("%s.%s", alias, rawType)
+		rawType = fmt.Sprintf("%s.%s", p.qualifier(field.GetPackage()), rawType)
 		typePackage = field.GetPackage()
 	} else {
 		// Handle types without a package defined
@@ -407,20 +483,17 @@ func (p *OrmPlugin) addIncludedField(ormable *OrmableType, field *gorm.ExtraFiel
 			// basic type, 100% okay, no imports or changes needed
 		} else if rawType == "Time" {
 			typePackage = stdTimeImport
-			p.fileImports["stdTimeImport"] = "time"
-			rawType = fmt.Sprintf("%s.Time", "stdTimeImport")
+			p.UsingGoImports(stdTimeImport)
+			rawType = "time.Time"
 		} else if rawType == "UUID" {
-			// rawType = fmt.Sprintf("%s.UUID", "uuidImport")
 			typePackage = uuidImport
-			p.fileImports["uuidImport"] = "github.com/satori/go.uuid"
+			rawType = fmt.Sprintf("%s.UUID", p.Import(uuidImport))
 		} else if field.GetType() == "Jsonb" && p.dbEngine == ENGINE_POSTGRES {
-			// rawType = fmt.Sprintf("%s.Jsonb", "gormpqImport")
 			typePackage = gormpqImport
-			p.fileImports["gormpqImport"] = "github.com/jinzhu/gorm/dialects/postgres"
+			rawType = fmt.Sprintf("%s.Jsonb", p.Import(gormpqImport))
 		} else if rawType == "Inet" {
-			// rawType = fmt.Sprintf("%s.Inet", "gtypesImport")
 			typePackage = gtypesImport
-			p.fileImports["gtypesImport"] = "github.com/TheSDTM/protoc-gen-gorm/types"
+			rawType = fmt.Sprintf("%s.Inet", p.Import(gtypesImport))
 		} else {
 			p.warning(`included field %q of type %q is not a recognized special type, and no package specified. This type is assumed to be in the same package as the generated code`,
 				string(field.GetName()), field.GetType())
@@ -433,20 +506,71 @@ func (p *OrmPlugin) addIncludedField(ormable *OrmableType, field *gorm.ExtraFiel
 	ormable.FieldsOrder = append(ormable.FieldsOrder, fieldName)
 }
 
-func (p *OrmPlugin) isOrmable(typeName string) bool {
-	parts := strings.Split(typeName, ".")
-	_, ok := p.ormableTypes[strings.Trim(parts[len(parts)-1], "[]*")]
+// declaredOrmable tells if the options of a message, or the
+// ormable_by_default option of its file, make it ormable
+func declaredOrmable(msg pgs.Message) bool {
+	if opts := getMessageOptions(msg); opts != nil && opts.Ormable != nil {
+		return opts.GetOrmable()
+	}
+	return getFileOptions(msg.File()).GetOrmableByDefault()
+}
+
+func (p *OrmPlugin) isOrmable(msg pgs.Message) bool {
+	_, ok := p.ormableTypes[msg.FullyQualifiedName()]
 	return ok
 }
 
-func (p *OrmPlugin) getOrmable(typeName string) *OrmableType {
-	parts := strings.Split(typeName, ".")
-	if ormable, ok := p.ormableTypes[strings.TrimSuffix(strings.Trim(parts[len(parts)-1], "[]*"), "ORM")]; ok {
+func (p *OrmPlugin) getOrmable(msg pgs.Message) *OrmableType {
+	if ormable, ok := p.ormableTypes[msg.FullyQualifiedName()]; ok {
 		return ormable
 	} else {
-		p.Fail(typeName, "is not ormable.")
+		p.Fail(msg.FullyQualifiedName(), " is not ormable.")
+		return nil
+	}
+}
+
+// lookupMessage resolves a message name of an option the way protoc resolves
+// type names: a name starting with a dot is fully qualified, others are looked
+// up from the scope of msg outwards. It returns nil for unknown names.
+func (p *OrmPlugin) lookupMessage(msg pgs.Message, name string) pgs.Message {
+	if strings.HasPrefix(name, ".") {
+		return p.messages[name]
+	}
+	scope := msg.FullyQualifiedName()
+	for {
+		if ref, ok := p.messages[scope+"."+name]; ok {
+			return ref
+		}
+		i := strings.LastIndex(scope, ".")
+		if i < 0 {
+			return nil
+		}
+		scope = scope[:i]
+	}
+}
+
+// fieldOrmable returns the ormable type held by a message or repeated message
+// field, nil if the field holds no ormable message
+func (p *OrmPlugin) fieldOrmable(field pgs.Field) *OrmableType {
+	msg := fieldMessage(field)
+	if msg == nil {
+		return nil
+	}
+	return p.ormableTypes[msg.FullyQualifiedName()]
+}
+
+// fieldMessage returns the message held by a message or repeated message
+// field, nil for other fields
+func fieldMessage(field pgs.Field) pgs.Message {
+	if field == nil {
 		return nil
 	}
+	if field.Type().IsEmbed() {
+		return field.Type().Embed()
+	} else if field.Type().IsRepeated() && field.Type().Element().IsEmbed() {
+		return field.Type().Element().Embed()
+	}
+	return nil
 }
 
 func (p *OrmPlugin) getSortedFieldNames(fields map[string]*Field) []string {
@@ -463,12 +587,13 @@ func (p *OrmPlugin) P(parts ...string) {
 	p.currentFileBuffer = append(p.currentFileBuffer, "\n")
 }
 
+// TypeName returns the Go type name of a message in its own package
 func (p *OrmPlugin) TypeName(msg pgs.Message) string {
-	return string(msg.Name())
+	return p.ctx.Name(msg).String()
 }
 
 func (p *OrmPlugin) generateOrmable(message pgs.Message) {
-	ormable := p.getOrmable(p.TypeName(message))
+	ormable := p.getOrmable(message)
 	p.P(`type `, ormable.Name, ` struct {`)
 	for _, fieldName := range ormable.FieldsOrder {
 		field := ormable.Fields[fieldName]
@@ -510,6 +635,8 @@ func (p *OrmPlugin) renderGormTag(field *Field) string {
 	}
 	if tag.GetAutoIncrement() {
 		gormRes += "autoIncrement;"
+	} else if tag.AutoIncrement != nil {
+		gormRes += "autoIncrement:false;"
 	}
 	if tag.Index != nil {
 		if tag.GetIndex() == "" {
@@ -525,6 +652,12 @@ func (p *OrmPlugin) renderGormTag(field *Field) string {
 			gormRes += fmt.Sprintf("uniqueIndex:%s;", tag.GetUniqueIndex())
 		}
 	}
+	for _, index := range field.Indexes {
+		gormRes += index + ";"
+	}
+	if field.SoftDelete == gorm.SoftDeleteMode_FLAG {
+		gormRes += "softDelete:flag;"
+	}
 	if tag.GetEmbedded() {
 		gormRes += "embedded;"
 	}
@@ -553,6 +686,12 @@ func (p *OrmPlugin) renderGormTag(field *Field) string {
 	if tag.Constraint != nil {
 		gormRes += "constraint:" + *tag.Constraint + ";"
 	}
+	if tag.GetAutoCreateTime() {
+		gormRes += "autoCreateTime;"
+	}
+	if tag.GetAutoUpdateTime() {
+		gormRes += "autoUpdateTime;"
+	}
 
 	var foreignKey, references, joinTable, joinForeignKey, joinReferences *string
 	if hasOne := field.GetHasOne(); hasOne != nil {
@@ -607,25 +746,64 @@ func (p *OrmPlugin) renderGormTag(field *Field) string {
 }
 
 // generateTableNameFunction the function to set the gorm table name
-// back to gorm default, removing "ORM" suffix
+// named after the message, without the "ORM" suffix
 func (p *OrmPlugin) generateTableNameFunction(message pgs.Message) {
 	typeName := p.TypeName(message)
 
 	p.P(`// TableName overrides the default tablename generated by GORM`)
 	p.P(`func (`, typeName, `ORM) TableName() string {`)
+	p.P(`return "`, p.tableName(message), `"`)
+	p.P(`}`)
+}
 
-	tableName := inflection.Plural(jgorm.ToDBName(string(message.Name())))
+// tableName returns the table of an ormable message: the table option of the
+// message if set, otherwise its Go type name in plural snake case, modified by
+// the naming strategy of its file
+func (p *OrmPlugin) tableName(message pgs.Message) string {
 	if opts := getMessageOptions(message); opts != nil && opts.Table != nil {
-		tableName = opts.GetTable()
+		return opts.GetTable()
+	}
+	naming := getFileOptions(message.File()).GetNaming()
+	tableName := jgorm.ToDBName(strings.Replace(p.TypeName(message), "_", "", -1))
+	if !naming.GetSingular() {
+		tableName = inflection.Plural(tableName)
+	}
+	if naming.GetCase() == gorm.NameCase_CAMEL_CASE {
+		tableName = pgs.Name(tableName).LowerCamelCase().String()
+	}
+	tableName = naming.GetTablePrefix() + tableName
+	if naming.GetSchema() != "" {
+		tableName = naming.GetSchema() + "." + tableName
+	}
+	return tableName
+}
+
+// applyColumnCase names the columns of an ormable type in lower camel case
+// when the naming strategy of its file asks for it, the column tag of a field
+// wins
+func applyColumnCase(ormable *OrmableType) {
+	if getFileOptions(ormable.File).GetNaming().GetColumnCase() != gorm.NameCase_CAMEL_CASE {
+		return
+	}
+	for fieldName, field := range ormable.Fields {
+		// associations and embedded types have no column of their own
+		if strings.HasSuffix(field.Type, "ORM") || field.GetTag().GetColumn() != "" {
+			continue
+		}
+		if field.GormFieldOptions == nil {
+			field.GormFieldOptions = &gorm.GormFieldOptions{}
+		}
+		if field.Tag == nil {
+			field.Tag = &gorm.GormTag{}
+		}
+		field.Tag.Column = proto.String(pgs.Name(jgorm.ToDBName(fieldName)).LowerCamelCase().String())
 	}
-	p.P(`return "`, tableName, `"`)
-	p.P(`}`)
 }
 
 // generateMapFunctions creates the converter functions
 func (p *OrmPlugin) generateConvertFunctions(message pgs.Message) {
 	typeName := p.TypeName(message)
-	ormable := p.getOrmable(generator.CamelCase(message.FullyQualifiedName()))
+	ormable := p.getOrmable(message)
 
 	///// To Orm
 	p.P(`// ToORM runs the BeforeToORM hook if present, converts the fields of this`)
@@ -643,6 +821,10 @@ func (p *OrmPlugin) generateConvertFunctions(message pgs.Message) {
 		if getFieldOptions(field).GetDrop() {
 			continue
 		}
+		if isSoftDeleteTime(message, field) {
+			p.generateSoftDeleteConversion(message, field, true)
+			continue
+		}
 		ofield := ormable.Fields[generator.CamelCase(string(field.Name()))]
 		p.generateFieldConversion(message, field, true, ofield)
 	}
@@ -670,6 +852,10 @@ func (p *OrmPlugin) generateConvertFunctions(message pgs.Message) {
 		if getFieldOptions(field).GetDrop() {
 			continue
 		}
+		if isSoftDeleteTime(message, field) {
+			p.generateSoftDeleteConversion(message, field, false)
+			continue
+		}
 		ofield := ormable.Fields[generator.CamelCase(string(field.Name()))]
 		p.generateFieldConversion(message, field, false, ofield)
 	}
@@ -684,24 +870,40 @@ func (p *OrmPlugin) generateConvertFunctions(message pgs.Message) {
 func (p *OrmPlugin) generateFieldConversion(message pgs.Message, field pgs.Field, toORM bool, ofield *Field) error {
 	fieldName := generator.CamelCase(string(field.Name()))
 	fieldType := string(p.ctx.Type(field))
-	if field.Type().IsRepeated() { // Repeated Object ----------------------------------
-		// Some repeated fields can be handled by github.com/lib/pq
-		if p.dbEngine == ENGINE_POSTGRES && p.IsAbleToMakePQArray(fieldType) {
-			p.P(`if m.Get`, fieldName, `() != nil {`)
-
-			switch fieldType {
-			case "[]bool":
-				p.P(`to.`, fieldName, ` = make(pqImport.BoolArray, len(m.`, fieldName, `))`)
-			case "[]float64":
-				p.P(`to.`, fieldName, ` = make(pqImport.Float64Array, len(m.`, fieldName, `))`)
-			case "[]int64":
-				p.P(`to.`, fieldName, ` = make(pqImport.Int64Array, len(m.`, fieldName, `))`)
-			case "[]string":
-				p.P(`to.`, fieldName, ` = make(pqImport.StringArray, len(m.`, fieldName, `))`)
+	if inRealOneOf(field) { // Oneof member, converted with the whole oneof
+		ormable := p.getOrmable(message)
+		if members := oneofMembers(ormable, field.OneOf()); len(members) > 0 && members[0].Name() == field.Name() {
+			p.generateOneofConversion(field.OneOf(), members, toORM)
+		}
+	} else if isOptionalScalar(field) { // Optional scalar or enum, NULL when unset
+		p.generateOptionalConversion(field, toORM)
+	} else if field.Type().IsMap() { // Map ---------------------------------------------------
+		if ofield != nil {
+			p.generateMapConversion(field, toORM)
+		}
+	} else if field.Type().IsRepeated() { // Repeated Object ----------------------------------
+		// Repeated scalars are stored in the array type of the engine
+		if array, ok := p.dialect.arrays[fieldType]; ok {
+			p.P(`if m.`, fieldName, ` != nil {`)
+			if toORM {
+				p.P(`to.`, fieldName, ` = make(`, p.mappedType(array), `, len(m.`, fieldName, `))`)
+			} else {
+				p.P(`to.`, fieldName, ` = make(`, fieldType, `, len(m.`, fieldName, `))`)
 			}
 			p.P(`copy(to.`, fieldName, `, m.`, fieldName, `)`)
 			p.P(`}`)
-		} else if p.isOrmable(fieldType) { // Repeated ORMable type
+		} else if field.Type().Element().IsEnum() { // Repeated enum, stored like a singular one
+			p.P(`if m.`, fieldName, ` != nil {`)
+			if toORM {
+				p.P(`to.`, fieldName, ` = make(`, p.mappedType(p.dialect.arrays["[]"+p.enumFormat(field).ormType()]), `, len(m.`, fieldName, `))`)
+			} else {
+				p.P(`to.`, fieldName, ` = make(`, fieldType, `, len(m.`, fieldName, `))`)
+			}
+			p.P(`for i, v := range m.`, fieldName, ` {`)
+			p.generateEnumConversion(field, "v", "to."+fieldName+"[i]", toORM)
+			p.P(`}`)
+			p.P(`}`)
+		} else if p.isOrmableField(field) { // Repeated ORMable type
 			//fieldType = strings.Trim(fieldType, "[]*")
 
 			p.P(`for _, v := range m.`, fieldName, ` {`)
@@ -722,66 +924,18 @@ func (p *OrmPlugin) generateFieldConversion(message pgs.Message, field pgs.Field
 		} else {
 			p.P(`// Repeated type `, fieldType, ` is not an ORMable message type`)
 		}
-	} else if field.Type().IsEnum() { // Singular Enum, which is an int32 ---
-		if toORM {
-			if p.stringEnums {
-				p.P(`to.`, fieldName, ` = `, fieldType, `_name[int32(m.`, fieldName, `)]`)
-			} else {
-				p.P(`to.`, fieldName, ` = int32(m.`, fieldName, `)`)
-			}
-		} else {
-			if p.stringEnums {
-				p.P(`to.`, fieldName, ` = `, fieldType, `(`, fieldType, `_value[m.`, fieldName, `])`)
-			} else {
-				p.P(`to.`, fieldName, ` = `, fieldType, `(m.`, fieldName, `)`)
-			}
-		}
-	} else if field.OneOf() != nil {
-		if toORM {
-			oneOf := field.OneOf()
-			for i := range oneOf.Fields() {
-				f := oneOf.Fields()[i]
-				fieldName = generator.CamelCase(string(f.Name()))
-				fieldType = string(p.ctx.Type(f))
-
-				if fieldType == "string" {
-					p.P(`if len(m.Get`, fieldName, `()) != 0 {`)
-					p.P(`to.`, fieldName, ` = m.Get`, fieldName, `()`)
-					p.P(`}`)
-				} else {
-					p.P(`if m.Get`, fieldName, `() != nil {`)
-					p.P(`tmpConv, _ := m.Get`, fieldName, `().ToORM(ctx)`)
-					p.P(`to.`, fieldName, ` = &tmpConv`)
-					p.P(`}`)
-				}
-			}
-		} else {
-			oneOf := field.OneOf()
-			oneOfFieldName := generator.CamelCase(string(oneOf.Name()))
-			msgName := generator.CamelCase(string(oneOf.Message().Name()))
-			for i := range oneOf.Fields() {
-				f := oneOf.Fields()[i]
-				fieldName = generator.CamelCase(string(f.Name()))
-				fieldType = string(p.ctx.Type(f))
-
-				if fieldType == "string" {
-					p.P(`if len(m.`, fieldName, `) != 0 {`)
-					p.P(`to.`, oneOfFieldName, ` = &`, msgName, `_`, fieldName, `{`, fieldName, `: m.`, fieldName, `}`)
-					p.P(`}`)
-				} else {
-					p.P(`if m.`, fieldName, ` != nil {`)
-					p.P(`tmpConv, _ := m.`, fieldName, `.ToPB(ctx)`)
-					p.P(`to.`, oneOfFieldName, ` = &`, msgName, `_`, fieldName, `{`, fieldName, `: &tmpConv`, `}`)
-					p.P(`}`)
-				}
-			}
-		}
+	} else if field.Type().IsEnum() { // Singular Enum, stored as a number or a name
+		p.generateEnumConversion(field, "m."+fieldName, "to."+fieldName, toORM)
+	} else if p.isSerialized(field) { // Serialized message ------------
+		p.generateSerializedConversion(field, "m."+fieldName, "to."+fieldName, toORM)
 	} else if field.Type().IsEmbed() { // Singular Object -------------
 		//Check for WKTs
 		parts := strings.Split(fieldType, ".")
 		coreType := parts[len(parts)-1]
 		// Type is a WKT, convert to/from as ptr to base type
-		if _, exists := wellKnownTypes[coreType]; exists { // Singular WKT -----
+		if wkt := wktName(field); wkt != "" { // Duration, BytesValue, Any and JSON WKTs
+			p.generateWKTConversion(field, wkt, "m."+fieldName, "to."+fieldName, toORM)
+		} else if _, exists := wellKnownTypes[coreType]; exists { // Singular WKT -----
 			if coreType == "Struct" {
 				if toORM {
 					p.P(`if m.Get`, fieldName, `() != nil {`)
@@ -790,6 +944,7 @@ func (p *OrmPlugin) generateFieldConversion(message pgs.Message, field pgs.Field
 					p.P(`for k, f := range fields {`)
 					p.P(`	converted[k] = f.AsInterface()`)
 					p.P(`}`)
+					p.UsingGoImports(encodingJsonImport)
 					p.P(`res, err := json.Marshal(converted)`)
 					p.P(`if err != nil {`)
 					p.P(`	return to, err`)
@@ -799,11 +954,12 @@ func (p *OrmPlugin) generateFieldConversion(message pgs.Message, field pgs.Field
 				} else {
 					p.P(`if m.`, fieldName, ` != nil && len(m.`, fieldName, `) > 0 {`)
 					p.P(`decoded := map[string]interface{}{}`)
+					p.UsingGoImports(encodingJsonImport)
 					p.P(`if err := json.Unmarshal(m.`, fieldName, `, &decoded); err != nil {`)
 					p.P(`	return to, err`)
 					p.P(`}`)
 					p.P(`var err error`)
-					p.P(`to.`, fieldName, `, err = _struct.NewStruct(decoded)`)
+					p.P(`to.`, fieldName, `, err = `, p.Import(structpbImport), `.NewStruct(decoded)`)
 					p.P(`if err != nil {`)
 					p.P(`	return to, err`)
 					p.P(`}`)
@@ -817,7 +973,7 @@ func (p *OrmPlugin) generateFieldConversion(message pgs.Message, field pgs.Field
 					p.P(`}`)
 				} else {
 					p.P(`if m.`, fieldName, ` != nil {`)
-					p.P(`to.`, fieldName, ` = &`, p.wktPkgName, ".", coreType,
+					p.P(`to.`, fieldName, ` = &`, p.messageTypeName(field.Type().Embed()),
 						`{Value: *m.`, fieldName, `}`)
 					p.P(`}`)
 				}
@@ -825,59 +981,60 @@ func (p *OrmPlugin) generateFieldConversion(message pgs.Message, field pgs.Field
 		} else if coreType == protoTypeUUIDValue { // Singular UUIDValue type ----
 			if toORM {
 				p.P(`if m.Get`, fieldName, `() != nil {`)
-				p.P(`tempUUID, uErr := `, "uuidImport", `.FromString(m.`, fieldName, `.Value)`)
+				p.P(`tempUUID, uErr := `, p.Import(uuidImport), `.FromString(m.`, fieldName, `.Value)`)
 				p.P(`if uErr != nil {`)
 				p.P(`return to, uErr`)
 				p.P(`}`)
 				p.P(`to.`, fieldName, ` = &tempUUID`)
 				p.P(`}`)
 			} else {
-				p.P(`if m.Get`, fieldName, `() != nil {`)
-				p.P(`to.`, fieldName, ` = &`, "gtypesImport", `.UUIDValue{Value: m.`, fieldName, `.String()}`)
+				p.P(`if m.`, fieldName, ` != nil {`)
+				p.P(`to.`, fieldName, ` = &`, p.Import(gtypesImport), `.UUIDValue{Value: m.`, fieldName, `.String()}`)
 				p.P(`}`)
 			}
 		} else if coreType == protoTypeUUID { // Singular UUID type --------------
 			if toORM {
 				p.P(`if m.Get`, fieldName, `() != nil {`)
-				p.P(`to.`, fieldName, `, err = `, "uuidImport", `.FromString(m.`, fieldName, `.Value)`)
+				p.P(`to.`, fieldName, `, err = `, p.Import(uuidImport), `.FromString(m.`, fieldName, `.Value)`)
 				p.P(`if err != nil {`)
 				p.P(`return to, err`)
 				p.P(`}`)
 				p.P(`} else {`)
-				p.P(`to.`, fieldName, ` = `, "uuidImport", `.Nil`)
+				p.P(`to.`, fieldName, ` = `, p.Import(uuidImport), `.Nil`)
 				p.P(`}`)
 			} else {
-				p.P(`to.`, fieldName, ` = &`, "gtypesImport", `.UUID{Value: m.`, fieldName, `.String()}`)
+				p.P(`to.`, fieldName, ` = &`, p.Import(gtypesImport), `.UUID{Value: m.`, fieldName, `.String()}`)
 			}
 		} else if coreType == protoTypeTimestamp { // Singular WKT Timestamp ---
-			p.fileImports["ptypesImport"] = "github.com/golang/protobuf/ptypes"
 			if toORM {
 				p.P(`if m.Get`, fieldName, `() != nil {`)
+				p.UsingGoImports(stdTimeImport)
 				p.P(`var t time.Time`)
-				p.P(`if t, err = `, "ptypesImport", `.Timestamp(m.`, fieldName, `); err != nil {`)
+				p.P(`if t, err = `, p.Import(ptypesImport), `.Timestamp(m.`, fieldName, `); err != nil {`)
 				p.P(`return to, err`)
 				p.P(`}`)
 				p.P(`to.`, fieldName, ` = &t`)
 				p.P(`}`)
 			} else {
 				p.P(`if m.`, fieldName, ` != nil {`)
-				p.P(`if to.`, fieldName, `, err = `, "ptypesImport", `.TimestampProto(*m.`, fieldName, `); err != nil {`)
+				p.P(`if to.`, fieldName, `, err = `, p.Import(ptypesImport), `.TimestampProto(*m.`, fieldName, `); err != nil {`)
 				p.P(`return to, err`)
 				p.P(`}`)
 				p.P(`}`)
 			}
 		} else if coreType == protoTypeJSON {
-			if p.dbEngine == ENGINE_POSTGRES {
+			if json := p.dialect.json; json != nil {
 				if toORM {
 					p.P(`if m.Get`, fieldName, `() != nil {`)
-					p.P(`to.`, fieldName, ` = &`, "gormpqImport", `.Jsonb{[]byte(m.`, fieldName, `.Value)}`)
+					p.P(`v := `, fmt.Sprintf(json.toORM, p.mappedType(json.typeMapping), "m."+fieldName+".Value"))
+					p.P(`to.`, fieldName, ` = &v`)
 					p.P(`}`)
 				} else {
-					p.P(`if m.Get`, fieldName, `() != nil {`)
-					p.P(`to.`, fieldName, ` = &`, "gtypesImport", `.JSONValue{Value: string(m.`, fieldName, `.RawMessage)}`)
+					p.P(`if m.`, fieldName, ` != nil {`)
+					p.P(`to.`, fieldName, ` = &`, p.Import(gtypesImport), `.JSONValue{Value: `, fmt.Sprintf(json.toPB, "m."+fieldName), `}`)
 					p.P(`}`)
 				}
-			} // Potential TODO other DB engine handling if desired
+			}
 		} else if coreType == protoTypeResource {
 			resource := "nil" // assuming we do not know the PB type, nil means call codec for any resource
 			if ofield != nil && ofield.ParentOriginName != "" {
@@ -886,8 +1043,7 @@ func (p *OrmPlugin) generateFieldConversion(message pgs.Message, field pgs.Field
 			btype := strings.TrimPrefix(ofield.Type, "*")
 			nillable := strings.HasPrefix(ofield.Type, "*")
 			iface := ofield.Type == "interface{}"
-
-			p.fileImports["resourceImport"] = "github.com/infobloxopen/atlas-app-toolkit/gorm/resource"
+			resourcePkg := p.Import(resourceImport)
 
 			if toORM {
 				if nillable {
@@ -895,7 +1051,7 @@ func (p *OrmPlugin) generateFieldConversion(message pgs.Message, field pgs.Field
 				}
 				switch btype {
 				case "int64":
-					p.P(`if v, err :=`, "resourceImport", `.DecodeInt64(`, resource, `, m.`, fieldName, `); err != nil {`)
+					p.P(`if v, err := `, resourcePkg, `.DecodeInt64(`, resource, `, m.`, fieldName, `); err != nil {`)
 					p.P(`	return to, err`)
 					p.P(`} else {`)
 					if nillable {
@@ -905,13 +1061,13 @@ func (p *OrmPlugin) generateFieldConversion(message pgs.Message, field pgs.Field
 					}
 					p.P(`}`)
 				case "[]byte":
-					p.P(`if v, err :=`, "resourceImport", `.DecodeBytes(`, resource, `, m.`, fieldName, `); err != nil {`)
+					p.P(`if v, err := `, resourcePkg, `.DecodeBytes(`, resource, `, m.`, fieldName, `); err != nil {`)
 					p.P(`	return to, err`)
 					p.P(`} else {`)
 					p.P(`	to.`, fieldName, ` = v`)
 					p.P(`}`)
 				default:
-					p.P(`if v, err :=`, "resourceImport", `.Decode(`, resource, `, m.`, fieldName, `); err != nil {`)
+					p.P(`if v, err := `, resourcePkg, `.Decode(`, resource, `, m.`, fieldName, `); err != nil {`)
 					p.P(`return to, err`)
 					p.P(`} else if v != nil {`)
 					if nillable {
@@ -932,7 +1088,7 @@ func (p *OrmPlugin) generateFieldConversion(message pgs.Message, field pgs.Field
 			if !toORM {
 				if nillable {
 					p.P(`if m.`, fieldName, `!= nil {`)
-					p.P(`	if v, err := `, "resourceImport", `.Encode(`, resource, `, *m.`, fieldName, `); err != nil {`)
+					p.P(`	if v, err := `, resourcePkg, `.Encode(`, resource, `, *m.`, fieldName, `); err != nil {`)
 					p.P(`		return to, err`)
 					p.P(`	} else {`)
 					p.P(`		to.`, fieldName, ` = v`)
@@ -940,7 +1096,7 @@ func (p *OrmPlugin) generateFieldConversion(message pgs.Message, field pgs.Field
 					p.P(`}`)
 
 				} else {
-					p.P(`if v, err := `, "resourceImport", `.Encode(`, resource, `, m.`, fieldName, `); err != nil {`)
+					p.P(`if v, err := `, resourcePkg, `.Encode(`, resource, `, m.`, fieldName, `); err != nil {`)
 					p.P(`return to, err`)
 					p.P(`} else {`)
 					p.P(`to.`, fieldName, ` = v`)
@@ -950,30 +1106,30 @@ func (p *OrmPlugin) generateFieldConversion(message pgs.Message, field pgs.Field
 		} else if coreType == protoTypeInet { // Inet type for Postgres only, currently
 			if toORM {
 				p.P(`if m.Get`, fieldName, `() != nil {`)
-				p.P(`if to.`, fieldName, `, err = `, "gtypesImport", `.ParseInet(m.`, fieldName, `.Value); err != nil {`)
+				p.P(`if to.`, fieldName, `, err = `, p.Import(gtypesImport), `.ParseInet(m.`, fieldName, `.Value); err != nil {`)
 				p.P(`return to, err`)
 				p.P(`}`)
 				p.P(`}`)
 			} else {
 				p.P(`if m.`, fieldName, ` != nil && m.`, fieldName, `.IPNet != nil {`)
-				p.P(`to.`, fieldName, ` = &`, "gtypesImport", `.InetValue{Value: m.`, fieldName, `.String()}`)
+				p.P(`to.`, fieldName, ` = &`, p.Import(gtypesImport), `.InetValue{Value: m.`, fieldName, `.String()}`)
 				p.P(`}`)
 			}
 		} else if coreType == protoTimeOnly { // Time only to support time via string
 			if toORM {
 				p.P(`if m.Get`, fieldName, `() != nil {`)
-				p.P(`if to.`, fieldName, `, err = `, "gtypesImport", `.ParseTime(m.`, fieldName, `.Value); err != nil {`)
+				p.P(`if to.`, fieldName, `, err = `, p.Import(gtypesImport), `.ParseTime(m.`, fieldName, `.Value); err != nil {`)
 				p.P(`return to, err`)
 				p.P(`}`)
 				p.P(`}`)
 			} else {
 				p.P(`if m.`, fieldName, ` != "" {`)
-				p.P(`if to.`, fieldName, `, err = `, "gtypesImport", `.TimeOnlyByString( m.`, fieldName, `); err != nil {`)
+				p.P(`if to.`, fieldName, `, err = `, p.Import(gtypesImport), `.TimeOnlyByString( m.`, fieldName, `); err != nil {`)
 				p.P(`return to, err`)
 				p.P(`}`)
 				p.P(`}`)
 			}
-		} else if p.isOrmable(fieldType) {
+		} else if p.isOrmableField(field) {
 			// Not a WKT, but a type we're building converters for
 			if toORM {
 				p.P(`if m.Get`, fieldName, `() != nil {`)
@@ -1013,8 +1169,10 @@ func (p *OrmPlugin) generateHookInterfaces(message pgs.Message) {
 	}
 }
 
+// warning logs a warning about a target file, the files which are only
+// parsed for their types are not reported
 func (p *OrmPlugin) warning(format string, v ...interface{}) {
-	if !p.suppressWarn {
+	if !p.suppressWarn && (p.currentFile == nil || p.targets[p.currentFile]) {
 		log.Printf("WARNING: "+format, v...)
 	}
 }
@@ -0,0 +1,392 @@
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	gorm "github.com/TheSDTM/protoc-gen-gorm/options"
+	"github.com/gogo/protobuf/protoc-gen-gogo/generator"
+	jgorm "github.com/jinzhu/gorm"
+	pgs "github.com/lyft/protoc-gen-star"
+)
+
+// Schema is the snapshot of the tables of a package on a DB engine, written
+// next to the DDL. The diff command migrates a database between two
+// snapshots.
+type Schema struct {
+	Engine string `json:"engine"`
+	// Files are the proto files of the package the tables are declared in
+	Files  []string       `json:"files,omitempty"`
+	Enums  []*SchemaEnum  `json:"enums,omitempty"`
+	Tables []*SchemaTable `json:"tables"`
+}
+
+// SchemaEnum is a native enum type
+type SchemaEnum struct {
+	Name   string   `json:"name"`
+	Values []string `json:"values"`
+}
+
+// SchemaTable is the table of an ormable type or a many-to-many join table
+type SchemaTable struct {
+	Name        string              `json:"name"`
+	Columns     []*SchemaColumn     `json:"columns"`
+	PrimaryKey  []string            `json:"primary_key,omitempty"`
+	Checks      []*SchemaCheck      `json:"checks,omitempty"`
+	Indexes     []*SchemaIndex      `json:"indexes,omitempty"`
+	ForeignKeys []*SchemaForeignKey `json:"foreign_keys,omitempty"`
+}
+
+// SchemaColumn is a column of a table, RenamedFrom is the renamed_from option
+// of its field
+type SchemaColumn struct {
+	Name          string  `json:"name"`
+	Type          string  `json:"type"`
+	AutoIncrement bool    `json:"auto_increment,omitempty"`
+	NotNull       bool    `json:"not_null,omitempty"`
+	Default       *string `json:"default,omitempty"`
+	Unique        bool    `json:"unique,omitempty"`
+	RenamedFrom   string  `json:"renamed_from,omitempty"`
+}
+
+// SchemaCheck is a check constraint, named if its tag names it
+type SchemaCheck struct {
+	Name       string `json:"name,omitempty"`
+	Expression string `json:"expression"`
+}
+
+// SchemaIndex is an index of the columns of a table. Sorts are the ASC or DESC
+// orders of the columns if any is set, Include the columns stored in the
+// index and Where the condition of a partial index.
+type SchemaIndex struct {
+	Name    string   `json:"name"`
+	Unique  bool     `json:"unique,omitempty"`
+	Class   string   `json:"class,omitempty"`
+	Type    string   `json:"type,omitempty"`
+	Columns []string `json:"columns"`
+	Sorts   []string `json:"sorts,omitempty"`
+	Include []string `json:"include,omitempty"`
+	Where   string   `json:"where,omitempty"`
+}
+
+// SchemaForeignKey is a foreign key constraint with its referential actions
+type SchemaForeignKey struct {
+	Name       string   `json:"name"`
+	Columns    []string `json:"columns"`
+	RefTable   string   `json:"ref_table"`
+	RefColumns []string `json:"ref_columns"`
+	OnUpdate   string   `json:"on_update,omitempty"`
+	OnDelete   string   `json:"on_delete,omitempty"`
+}
+
+func (t *SchemaTable) column(name string) *SchemaColumn {
+	for _, column := range t.Columns {
+		if column.Name == name {
+			return column
+		}
+	}
+	return nil
+}
+
+// fileSchema returns the native enums and the tables of the ormable types of
+// a file, followed by the join tables of their many-to-many associations
+func (p *OrmPlugin) fileSchema(d *ddlDialect, file pgs.File) *Schema {
+	schema := &Schema{Engine: engineName(p.ddlEngine)}
+	if p.dialect.nativeEnumDDL != "" {
+		for _, enum := range p.nativeEnums(file) {
+			schema.Enums = append(schema.Enums, &SchemaEnum{Name: nativeEnumName(enum), Values: enumValues(enum, enumPrefix(enum))})
+		}
+	}
+	foreignKeys, joinTables := p.relations(d, file)
+	for _, msg := range file.AllMessages() {
+		if p.isOrmable(msg) {
+			table := p.schemaTable(d, msg)
+			table.ForeignKeys = foreignKeys[table.Name]
+			schema.Tables = append(schema.Tables, table)
+		}
+	}
+	schema.Tables = append(schema.Tables, joinTables...)
+	return schema
+}
+
+// schemaTable returns the table of an ormable message
+func (p *OrmPlugin) schemaTable(d *ddlDialect, msg pgs.Message) *SchemaTable {
+	table := &SchemaTable{Name: p.tableName(msg)}
+	ormable := p.getOrmable(msg)
+	keys := map[string]bool{}
+	for _, pkName := range p.primaryKeys(ormable) {
+		keys[pkName] = true
+	}
+	byName := map[string]*SchemaIndex{}
+	priorities := map[*SchemaIndex][]int{}
+	addIndex := func(value string, unique bool, column string) {
+		name, priority, isUnique := parseIndexTag(value)
+		if name == "" {
+			name = fmt.Sprintf("idx_%s_%s", unqualifiedTable(table.Name), column)
+		}
+		index, ok := byName[name]
+		if !ok {
+			index = &SchemaIndex{Name: name}
+			byName[name] = index
+			table.Indexes = append(table.Indexes, index)
+		}
+		index.Unique = index.Unique || unique || isUnique
+		index.Columns = append(index.Columns, column)
+		priorities[index] = append(priorities[index], priority)
+	}
+	for _, c := range p.ddlColumns(msg, "") {
+		tag := c.field.GetTag()
+		if tag == nil {
+			tag = &gorm.GormTag{}
+		}
+		isKey := !c.embedded && keys[c.fieldName]
+		keyed := isKey || tag.GetUnique() || tag.Index != nil || tag.UniqueIndex != nil || len(c.field.Indexes) > 0 || c.field.ParentOriginName != ""
+		column := &SchemaColumn{
+			Name:          c.name,
+			Type:          p.ddlColumnType(d, ormable, c.fieldName, c.field, keyed),
+			AutoIncrement: tag.GetAutoIncrement() || isKey && len(keys) == 1 && tag.AutoIncrement == nil && isIntegerType(c.field.Type),
+			NotNull:       isKey || tag.GetNotNull(),
+			Default:       tag.Default,
+			Unique:        tag.GetUnique(),
+			RenamedFrom:   c.renamedFrom,
+		}
+		table.Columns = append(table.Columns, column)
+		if isKey {
+			table.PrimaryKey = append(table.PrimaryKey, column.Name)
+		}
+		if tag.Check != nil {
+			table.Checks = append(table.Checks, parseCheckTag(tag.GetCheck()))
+		}
+		if tag.Index != nil {
+			addIndex(tag.GetIndex(), false, column.Name)
+		}
+		if tag.UniqueIndex != nil {
+			addIndex(tag.GetUniqueIndex(), true, column.Name)
+		}
+	}
+	for _, index := range table.Indexes {
+		columns, order := index.Columns, priorities[index]
+		sorted := make([]int, len(columns))
+		for i := range sorted {
+			sorted[i] = i
+		}
+		sort.SliceStable(sorted, func(i, j int) bool { return order[sorted[i]] < order[sorted[j]] })
+		index.Columns = make([]string, len(columns))
+		for i, k := range sorted {
+			index.Columns[i] = columns[k]
+		}
+	}
+	for _, definition := range ormable.Indexes {
+		table.Indexes = append(table.Indexes, schemaIndex(ormable, definition))
+	}
+	return table
+}
+
+// schemaIndex returns the index of an index definition of the message options
+func schemaIndex(ormable *OrmableType, definition *gorm.IndexDefinition) *SchemaIndex {
+	column := func(name string) string {
+		fieldName := generator.CamelCase(name)
+		return columnName(fieldName, ormable.Fields[fieldName])
+	}
+	index := &SchemaIndex{
+		Name:   definition.GetName(),
+		Unique: definition.GetUnique(),
+		Class:  strings.ToUpper(definition.GetClass()),
+		Type:   definition.GetType(),
+		Where:  definition.GetWhere(),
+	}
+	sorted := false
+	for _, c := range definition.GetColumns() {
+		index.Columns = append(index.Columns, column(c.GetField()))
+		index.Sorts = append(index.Sorts, strings.ToUpper(c.GetSort()))
+		sorted = sorted || c.GetSort() != ""
+	}
+	if !sorted {
+		index.Sorts = nil
+	}
+	for _, include := range definition.GetInclude() {
+		index.Include = append(index.Include, column(include))
+	}
+	return index
+}
+
+// relations returns the foreign keys of the tables of the file by table and
+// the join tables of the many-to-many associations of its messages. Has-one
+// and has-many associations constrain the table of the associated type,
+// which may be declared by any file.
+func (p *OrmPlugin) relations(d *ddlDialect, file pgs.File) (map[string][]*SchemaForeignKey, []*SchemaTable) {
+	foreignKeys := map[string][]*SchemaForeignKey{}
+	var joinTables []*SchemaTable
+	joinTableNames := map[string]bool{}
+	for _, f := range p.files {
+		for _, msg := range f.AllMessages() {
+			if !p.isOrmable(msg) {
+				continue
+			}
+			ormable := p.getOrmable(msg)
+			table := p.tableName(msg)
+			for _, field := range msg.Fields() {
+				fieldName := generator.CamelCase(string(field.Name()))
+				assocField, ok := ormable.Fields[fieldName]
+				assoc := p.fieldOrmable(field)
+				if !ok || assoc == nil || assocField.GetTag().GetEmbedded() {
+					continue
+				}
+				assocTable := p.tableName(fieldMessage(field))
+				fk := &SchemaForeignKey{Name: fmt.Sprintf("fk_%s_%s", unqualifiedTable(table), jgorm.ToDBName(fieldName))}
+				fk.OnUpdate, fk.OnDelete = parseConstraintTag(assocField.GetTag().GetConstraint())
+				switch {
+				case assocField.GetHasOne() != nil || assocField.GetHasMany() != nil:
+					if fieldMessage(field).File() != file {
+						continue
+					}
+					foreignKey, references := assocField.GetHasOne().GetForeignKey(), assocField.GetHasOne().GetReferences()
+					if hasMany := assocField.GetHasMany(); hasMany != nil {
+						foreignKey, references = hasMany.GetForeignKey(), hasMany.GetReferences()
+					}
+					fk.Columns = keyColumns(assoc, foreignKey)
+					fk.RefTable, fk.RefColumns = table, keyColumns(ormable, references)
+					foreignKeys[assocTable] = append(foreignKeys[assocTable], fk)
+				case assocField.GetBelongsTo() != nil:
+					if f != file {
+						continue
+					}
+					belongsTo := assocField.GetBelongsTo()
+					fk.Columns = keyColumns(ormable, belongsTo.GetForeignKey())
+					fk.RefTable, fk.RefColumns = assocTable, keyColumns(assoc, belongsTo.GetReferences())
+					foreignKeys[table] = append(foreignKeys[table], fk)
+				case assocField.GetManyToMany() != nil:
+					mtm := assocField.GetManyToMany()
+					if f != file || joinTableNames[mtm.GetJointable()] {
+						continue
+					}
+					joinTableNames[mtm.GetJointable()] = true
+					jt := &SchemaTable{Name: mtm.GetJointable()}
+					for _, ref := range []struct {
+						columns    string
+						table      string
+						ormable    *OrmableType
+						fieldNames string
+					}{
+						{mtm.GetJoinForeignKey(), table, ormable, mtm.GetForeignKey()},
+						{mtm.GetJoinReferences(), assocTable, assoc, mtm.GetReferences()},
+					} {
+						columns, fieldNames := keyNames(ref.columns), keyNames(ref.fieldNames)
+						for i := range columns {
+							columns[i] = jgorm.ToDBName(columns[i])
+							key := ref.ormable.Fields[fieldNames[i]]
+							jt.Columns = append(jt.Columns, &SchemaColumn{
+								Name:    columns[i],
+								Type:    p.ddlColumnType(d, ref.ormable, fieldNames[i], key, true),
+								NotNull: true,
+							})
+						}
+						jt.PrimaryKey = append(jt.PrimaryKey, columns...)
+						jt.ForeignKeys = append(jt.ForeignKeys, &SchemaForeignKey{
+							Name:       fmt.Sprintf("fk_%s_%s", jt.Name, strings.Join(columns, "_")),
+							Columns:    columns,
+							RefTable:   ref.table,
+							RefColumns: keyColumns(ref.ormable, ref.fieldNames),
+							OnUpdate:   fk.OnUpdate,
+							OnDelete:   fk.OnDelete,
+						})
+					}
+					joinTables = append(joinTables, jt)
+				}
+			}
+		}
+	}
+	return foreignKeys, joinTables
+}
+
+// keyColumns returns the columns of the comma separated keys of an ormable type
+func keyColumns(ormable *OrmableType, keys string) []string {
+	var columns []string
+	for _, key := range keyNames(keys) {
+		columns = append(columns, columnName(key, ormable.Fields[key]))
+	}
+	return columns
+}
+
+// generateSnapshots outputs the schema snapshot of every package of the
+// targets, the tables of all the loaded files of the package in file name
+// order, so the snapshot does not depend on which files are targets
+func (p *OrmPlugin) generateSnapshots(d *ddlDialect, targets map[string]pgs.File) {
+	packages := map[pgs.Package]bool{}
+	for _, file := range targets {
+		packages[file.Package()] = true
+	}
+	for pkg := range packages {
+		files := append([]pgs.File(nil), pkg.Files()...)
+		sort.Slice(files, func(i, j int) bool { return files[i].Name() < files[j].Name() })
+		snapshot := &Schema{Engine: engineName(p.ddlEngine), Tables: []*SchemaTable{}}
+		for _, file := range files {
+			snapshot.Files = append(snapshot.Files, file.Name().String())
+			p.setFile(file)
+			schema := p.fileSchema(d, file)
+			snapshot.Enums = append(snapshot.Enums, schema.Enums...)
+			snapshot.Tables = append(snapshot.Tables, schema.Tables...)
+		}
+		if len(snapshot.Tables) == 0 {
+			continue
+		}
+		var b strings.Builder
+		// checks and defaults are SQL, keep their < > & readable
+		encoder := json.NewEncoder(&b)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(snapshot); err != nil {
+			p.Failf("cannot encode the schema snapshot of %s: %s", pkg.ProtoName(), err)
+		}
+		fileName := path.Join(p.ctx.OutputPath(files[0]).Dir().String(), pkg.ProtoName().String()+".schema.json")
+		p.AddGeneratorFile(fileName, b.String())
+	}
+}
+
+// parseIndexTag returns the name, the priority and the uniqueness set by the
+// value of an index tag like "idx_name,unique,priority:2"
+func parseIndexTag(value string) (name string, priority int, unique bool) {
+	priority = 10
+	for i, setting := range strings.Split(value, ",") {
+		setting = strings.TrimSpace(setting)
+		switch {
+		case strings.EqualFold(setting, "unique"):
+			unique = true
+		case strings.HasPrefix(strings.ToLower(setting), "priority:"):
+			fmt.Sscanf(setting[len("priority:"):], "%d", &priority)
+		case i == 0:
+			name = setting
+		}
+	}
+	return name, priority, unique
+}
+
+// parseCheckTag returns the check constraint of a check tag, either an
+// expression or a constraint name and an expression separated by a comma
+func parseCheckTag(check string) *SchemaCheck {
+	if parts := strings.SplitN(check, ",", 2); len(parts) == 2 && !strings.ContainsAny(parts[0], " <>=()") {
+		return &SchemaCheck{Name: parts[0], Expression: strings.TrimSpace(parts[1])}
+	}
+	return &SchemaCheck{Expression: check}
+}
+
+// parseConstraintTag returns the referential actions of a constraint tag like
+// "OnUpdate:CASCADE,OnDelete:SET NULL"
+func parseConstraintTag(constraint string) (onUpdate string, onDelete string) {
+	for _, setting := range strings.Split(constraint, ",") {
+		parts := strings.SplitN(setting, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		switch strings.ToUpper(strings.TrimSpace(parts[0])) {
+		case "ONUPDATE":
+			onUpdate = strings.TrimSpace(parts[1])
+		case "ONDELETE":
+			onDelete = strings.TrimSpace(parts[1])
+		}
+	}
+	return onUpdate, onDelete
+}
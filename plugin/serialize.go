@@ -0,0 +1,44 @@
+package plugin
+
+import (
+	gorm "github.com/TheSDTM/protoc-gen-gorm/options"
+	pgs "github.com/lyft/protoc-gen-star"
+)
+
+// isSerialized tells if the field is a non-ormable message stored in a single
+// column with (gorm.field).serialize
+func (p *OrmPlugin) isSerialized(field pgs.Field) bool {
+	opts := getFieldOptions(field)
+	return opts != nil && opts.Serialize != nil && field.Type().IsEmbed() && !p.isOrmableField(field)
+}
+
+// serializedMapping returns the ORM representation of a serialized field
+func (p *OrmPlugin) serializedMapping(field pgs.Field) typeMapping {
+	if getFieldOptions(field).GetSerialize() == gorm.SerializeFormat_PROTO_BINARY {
+		return typeMapping{goType: "[]byte", column: p.dialect.binaryColumn}
+	}
+	return p.dialect.document
+}
+
+// generateSerializedConversion outputs the conversion of a serialized field
+// from the src expression to the dst one
+func (p *OrmPlugin) generateSerializedConversion(field pgs.Field, src string, dst string, toORM bool) {
+	pkg := p.Import(protojsonImport)
+	if getFieldOptions(field).GetSerialize() == gorm.SerializeFormat_PROTO_BINARY {
+		pkg = p.Import(protoImport)
+	}
+	p.P(`if `, src, ` != nil {`)
+	if toORM {
+		p.P(`data, err := `, pkg, `.Marshal(`, src, `)`)
+		p.P(`if err != nil {`)
+		p.P(`return to, err`)
+		p.P(`}`)
+		p.P(dst, ` = data`)
+	} else {
+		p.P(dst, ` = &`, p.messageTypeName(field.Type().Embed()), `{}`)
+		p.P(`if err = `, pkg, `.Unmarshal(`, src, `, `, dst, `); err != nil {`)
+		p.P(`return to, err`)
+		p.P(`}`)
+	}
+	p.P(`}`)
+}
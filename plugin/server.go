@@ -0,0 +1,297 @@
+package plugin
+
+import (
+	"strings"
+
+	"github.com/gogo/protobuf/protoc-gen-gogo/generator"
+	pgs "github.com/lyft/protoc-gen-star"
+)
+
+const (
+	createService = "Create"
+	readService   = "Read"
+	updateService = "Update"
+	deleteService = "Delete"
+	listService   = "List"
+)
+
+// generateDefaultServer outputs a <Service>DefaultServer type implementing
+// every method of a service with (gorm.server).autogen set. Methods following
+// the CRUDL naming conventions call the default handlers, all others are stubs.
+// The type embeds the Unimplemented<Service>Server of the gRPC generated code,
+// so it keeps implementing the service when methods are added.
+func (p *OrmPlugin) generateDefaultServer(service pgs.Service) {
+	if !getServiceOptions(service).GetAutogen() {
+		return
+	}
+	serverName := string(service.Name()) + "DefaultServer"
+	p.txnMiddleware = getServiceOptions(service).GetTxnMiddleware()
+
+	p.P(`type `, serverName, ` struct {`)
+	p.P(`Unimplemented`, p.ctx.ServerName(service).String())
+	if !p.txnMiddleware {
+		p.P(`DB *`, p.Import(gormImport), `.DB`)
+	}
+	p.P(`}`)
+	p.P()
+	for _, method := range service.Methods() {
+		methodName := string(method.Name())
+		switch {
+		case method.ClientStreaming() || method.ServerStreaming():
+			p.generateStreamingStub(serverName, method)
+		case strings.HasPrefix(methodName, createService) && p.generateCreateServerMethod(serverName, method):
+		case strings.HasPrefix(methodName, readService) && p.generateReadServerMethod(serverName, method):
+		case strings.HasPrefix(methodName, updateService) && p.generateUpdateServerMethod(serverName, method):
+		case strings.HasPrefix(methodName, deleteService) && p.generateDeleteServerMethod(serverName, method):
+		case strings.HasPrefix(methodName, listService) && p.generateListServerMethod(serverName, method):
+		default:
+			p.generateMethodStub(serverName, method)
+		}
+	}
+}
+
+func (p *OrmPlugin) generateCreateServerMethod(serverName string, method pgs.Method) bool {
+	payload := getMessageField(method.Input(), "payload")
+	if !p.isOrmableField(payload) || !p.sameFieldType(payload, getMessageField(method.Output(), "result")) {
+		p.warning(`method %s does not follow the Create conventions, a stub is generated`, method.FullyQualifiedName())
+		return false
+	}
+	typeName := p.fieldTypeName(payload)
+	p.generateMethodSignature(serverName, method, "creates the payload of the request")
+	p.generateDBSetup()
+	p.P(`res, err := `, qualifiedFunc(typeName, "DefaultCreate"), `(ctx, in.Get`, generator.CamelCase(string(payload.Name())), `(), db)`)
+	p.P(`if err != nil {`)
+	p.P(`return nil, err`)
+	p.P(`}`)
+	p.P(`return &`, p.messageTypeName(method.Output()), `{Result: res}, nil`)
+	p.P(`}`)
+	p.P()
+	return true
+}
+
+func (p *OrmPlugin) generateReadServerMethod(serverName string, method pgs.Method) bool {
+	result := getMessageField(method.Output(), "result")
+	if !p.isOrmableField(result) {
+		p.warning(`method %s does not follow the Read conventions, a stub is generated`, method.FullyQualifiedName())
+		return false
+	}
+	typeName := p.fieldTypeName(result)
+	idLiteral, ok := p.idLiteral(method.Input(), result.Type().Embed(), typeName)
+	if !ok {
+		p.warning(`method %s does not follow the Read conventions, a stub is generated`, method.FullyQualifiedName())
+		return false
+	}
+	p.generateMethodSignature(serverName, method, "reads the object with the id of the request")
+	p.generateDBSetup()
+	p.P(`res, err := `, qualifiedFunc(typeName, "DefaultRead"), `(ctx, `, idLiteral, `, db)`)
+	p.P(`if err != nil {`)
+	p.P(`return nil, err`)
+	p.P(`}`)
+	p.P(`return &`, p.messageTypeName(method.Output()), `{Result: res}, nil`)
+	p.P(`}`)
+	p.P()
+	return true
+}
+
+func (p *OrmPlugin) generateUpdateServerMethod(serverName string, method pgs.Method) bool {
+	payload := getMessageField(method.Input(), "payload")
+	if !p.isOrmableField(payload) || !p.sameFieldType(payload, getMessageField(method.Output(), "result")) ||
+		!p.hasPrimaryKey(p.fieldOrmable(payload)) {
+		p.warning(`method %s does not follow the Update conventions, a stub is generated`, method.FullyQualifiedName())
+		return false
+	}
+	typeName := p.fieldTypeName(payload)
+	payloadName := generator.CamelCase(string(payload.Name()))
+	if mask := getFieldMaskField(method.Input()); mask != nil {
+		p.generateMethodSignature(serverName, method, "writes the fields of the payload of the request listed in its update mask")
+		p.generateDBSetup()
+		p.P(`res, err := `, qualifiedFunc(typeName, "DefaultPatch"), `(ctx, in.Get`, payloadName, `(), in.Get`, generator.CamelCase(string(mask.Name())), `(), db)`)
+	} else {
+		p.generateMethodSignature(serverName, method, "overwrites the object with the payload of the request")
+		p.generateDBSetup()
+		p.P(`res, err := `, qualifiedFunc(typeName, "DefaultUpdate"), `(ctx, in.Get`, payloadName, `(), db)`)
+	}
+	p.P(`if err != nil {`)
+	p.P(`return nil, err`)
+	p.P(`}`)
+	p.P(`return &`, p.messageTypeName(method.Output()), `{Result: res}, nil`)
+	p.P(`}`)
+	p.P()
+	return true
+}
+
+func (p *OrmPlugin) generateDeleteServerMethod(serverName string, method pgs.Method) bool {
+	objectType := getMethodOptions(method).GetObjectType()
+	var object pgs.Message
+	prefix := "."
+	if pkg := method.Package().ProtoName().String(); pkg != "" {
+		prefix += pkg + "."
+	}
+	for _, file := range method.Package().Files() {
+		for _, msg := range file.AllMessages() {
+			// nested types are named relative to the package, e.g. Outer.Inner
+			if strings.TrimPrefix(msg.FullyQualifiedName(), prefix) == objectType && p.isOrmable(msg) {
+				object = msg
+			}
+		}
+	}
+	if object == nil {
+		p.warning(`method %s has no ormable (gorm.method).object_type set, a stub is generated`, method.FullyQualifiedName())
+		return false
+	}
+	idLiteral, ok := p.idLiteral(method.Input(), object, p.TypeName(object))
+	if !ok {
+		p.warning(`method %s does not follow the Delete conventions, a stub is generated`, method.FullyQualifiedName())
+		return false
+	}
+	p.generateMethodSignature(serverName, method, "deletes the object with the id of the request")
+	p.generateDBSetup()
+	p.P(`if err := DefaultDelete`, p.TypeName(object), `(ctx, `, idLiteral, `, db); err != nil {`)
+	p.P(`return nil, err`)
+	p.P(`}`)
+	p.P(`return &`, p.messageTypeName(method.Output()), `{}, nil`)
+	p.P(`}`)
+	p.P()
+	return true
+}
+
+func (p *OrmPlugin) generateListServerMethod(serverName string, method pgs.Method) bool {
+	results := getMessageField(method.Output(), "results")
+	if results == nil || !results.Type().IsRepeated() || !p.isOrmableField(results) {
+		p.warning(`method %s does not follow the List conventions, a stub is generated`, method.FullyQualifiedName())
+		return false
+	}
+	typeName := strings.TrimPrefix(p.fieldTypeName(results), "[]")
+	p.generateMethodSignature(serverName, method, "lists every object")
+	p.generateDBSetup()
+	p.P(`res, err := `, qualifiedFunc(typeName, "DefaultList"), `(ctx, db)`)
+	p.P(`if err != nil {`)
+	p.P(`return nil, err`)
+	p.P(`}`)
+	p.P(`return &`, p.messageTypeName(method.Output()), `{Results: res}, nil`)
+	p.P(`}`)
+	p.P()
+	return true
+}
+
+// generateDBSetup outputs the db variable used by a server method: the
+// transaction from the context with txn_middleware, the server DB otherwise
+func (p *OrmPlugin) generateDBSetup() {
+	if !p.txnMiddleware {
+		p.P(`db := m.DB`)
+		return
+	}
+	p.P(`db, err := `, p.Import(tgormImport), `.BeginFromContext(ctx)`)
+	p.P(`if err != nil {`)
+	p.P(`return nil, err`)
+	p.P(`}`)
+}
+
+// generateMethodStub outputs a method returning the Unimplemented status, to
+// be overridden by a type embedding the server
+func (p *OrmPlugin) generateMethodStub(serverName string, method pgs.Method) {
+	p.generateMethodSignature(serverName, method, "is not implemented by the default server, override it in a type embedding the server")
+	p.P(`return nil, `, p.unimplementedError(method))
+	p.P(`}`)
+	p.P()
+}
+
+func (p *OrmPlugin) generateStreamingStub(serverName string, method pgs.Method) {
+	methodName := p.ctx.Name(method).String()
+	p.P(`// `, methodName, ` is not implemented by the default server, override it in a type embedding the server`)
+	if method.ClientStreaming() {
+		p.P(`func (m *`, serverName, `) `, methodName, `(stream `, p.ctx.ServerStream(method).String(), `) error {`)
+	} else {
+		p.P(`func (m *`, serverName, `) `, methodName, `(in *`, p.messageTypeName(method.Input()), `, stream `, p.ctx.ServerStream(method).String(), `) error {`)
+	}
+	p.P(`return `, p.unimplementedError(method))
+	p.P(`}`)
+	p.P()
+}
+
+// unimplementedError returns the expression of the error of a method stub
+func (p *OrmPlugin) unimplementedError(method pgs.Method) string {
+	return p.Import(grpcStatusImport) + `.Error(` + p.Import(grpcCodesImport) + `.Unimplemented, "method ` +
+		p.ctx.Name(method).String() + ` not implemented")`
+}
+
+// generateMethodSignature outputs the doc comment of a server method, telling
+// what the method does, and its signature
+func (p *OrmPlugin) generateMethodSignature(serverName string, method pgs.Method, doc string) {
+	methodName := p.ctx.Name(method).String()
+	p.P(`// `, methodName, ` `, doc)
+	p.P(`func (m *`, serverName, `) `, methodName, `(ctx context.Context, in *`, p.messageTypeName(method.Input()),
+		`) (*`, p.messageTypeName(method.Output()), `, error) {`)
+}
+
+// idLiteral returns the expression building an object of the given type from
+// the id field of the request, if the field matches the primary key of the type.
+// An id cannot stand for a composite key.
+func (p *OrmPlugin) idLiteral(request pgs.Message, object pgs.Message, typeName string) (string, bool) {
+	id := getMessageField(request, "id")
+	ormable := p.getOrmable(object)
+	keys := p.primaryKeys(ormable)
+	if id == nil || len(keys) != 1 {
+		return "", false
+	}
+	pkName := keys[0]
+	for _, field := range object.Fields() {
+		if generator.CamelCase(string(field.Name())) == pkName && p.sameFieldType(id, field) {
+			return "&" + strings.TrimPrefix(typeName, "*") + "{" + pkName + ": in.Get" + generator.CamelCase(string(id.Name())) + "()}", true
+		}
+	}
+	return "", false
+}
+
+// isOrmableField tells if the field holds an ormable message
+func (p *OrmPlugin) isOrmableField(field pgs.Field) bool {
+	return p.fieldOrmable(field) != nil
+}
+
+func (p *OrmPlugin) sameFieldType(field1 pgs.Field, field2 pgs.Field) bool {
+	return field1 != nil && field2 != nil && p.ctx.Type(field1) == p.ctx.Type(field2)
+}
+
+// fieldTypeName returns the Go type of a message field without the pointer,
+// registering the import of the message package if it is not the current one
+func (p *OrmPlugin) fieldTypeName(field pgs.Field) string {
+	typeName := strings.Replace(p.ctx.Type(field).String(), "*", "", -1)
+	if embed := field.Type().Embed(); embed != nil {
+		p.messageTypeName(embed)
+	} else if el := field.Type().Element(); el != nil && el.IsEmbed() {
+		p.messageTypeName(el.Embed())
+	}
+	return typeName
+}
+
+// messageTypeName returns the Go type of a message, qualified and imported if
+// it is defined in another package than the current file
+func (p *OrmPlugin) messageTypeName(msg pgs.Message) string {
+	name := p.ctx.Name(msg).String()
+	if p.ctx.ImportPath(msg) == p.ctx.ImportPath(p.currentFile) {
+		return name
+	}
+	pkg := p.ctx.PackageName(msg).String()
+	p.importAs(pkg, p.ctx.ImportPath(msg).String())
+	return pkg + "." + name
+}
+
+func getMessageField(msg pgs.Message, name string) pgs.Field {
+	for _, field := range msg.Fields() {
+		if string(field.Name()) == name {
+			return field
+		}
+	}
+	return nil
+}
+
+// getFieldMaskField returns the first google.protobuf.FieldMask field of a
+// message, whatever its name
+func getFieldMaskField(msg pgs.Message) pgs.Field {
+	for _, field := range msg.Fields() {
+		if field.Type().IsEmbed() && field.Type().Embed().FullyQualifiedName() == ".google.protobuf.FieldMask" {
+			return field
+		}
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package plugin
+
+import (
+	"github.com/gogo/protobuf/protoc-gen-gogo/generator"
+	pgs "github.com/lyft/protoc-gen-star"
+	"google.golang.org/protobuf/proto"
+
+	gorm "github.com/TheSDTM/protoc-gen-gorm/options"
+)
+
+// softDeleteTime is the proto field exposing the deletion time of a soft
+// deleted row, it has no column of its own
+const softDeleteTime = "delete_time"
+
+// isSoftDeleteTime tells if the field of a message exposes its soft delete
+// mark
+func isSoftDeleteTime(message pgs.Message, field pgs.Field) bool {
+	return getMessageOptions(message).GetSoftDelete() && string(field.Name()) == softDeleteTime
+}
+
+// parseSoftDelete gives the ormable type of a soft deleted message the field
+// marking its deleted rows: a gorm.DeletedAt, or a soft_delete.DeletedAt of
+// the soft delete plugin of GORM holding a unix time or a flag
+func (p *OrmPlugin) parseSoftDelete(msg pgs.Message) {
+	opts := getMessageOptions(msg)
+	if !opts.GetSoftDelete() {
+		return
+	}
+	ormable := p.getOrmable(msg)
+	column := opts.GetSoftDeleteColumn()
+	if column == "" {
+		column = "deleted_at"
+	}
+	fieldName := generator.CamelCase(column)
+	if _, ok := ormable.Fields[fieldName]; ok {
+		p.Failf("the soft delete field %s of %s already exists, set another soft_delete_column", fieldName, ormable.Name)
+	}
+	mode := opts.GetSoftDeleteMode()
+	field := &Field{SoftDelete: mode, GormFieldOptions: &gorm.GormFieldOptions{Tag: &gorm.GormTag{Index: proto.String("")}}}
+	if opts.SoftDeleteColumn != nil {
+		field.Tag.Column = proto.String(column)
+	}
+	if mode == gorm.SoftDeleteMode_DELETED_AT {
+		field.Type, field.Package = p.Import(gormImport)+".DeletedAt", gormImport
+	} else {
+		field.Type, field.Package = p.Import(softDeleteImport)+".DeletedAt", softDeleteImport
+		field.Tag.NotNull, field.Tag.Default = proto.Bool(true), proto.String("0")
+	}
+	for _, f := range msg.Fields() {
+		if !isSoftDeleteTime(msg, f) || getFieldOptions(f).GetDrop() {
+			continue
+		}
+		if mode == gorm.SoftDeleteMode_FLAG {
+			p.Failf("the soft delete flag of %s has no time for its %s field", ormable.Name, softDeleteTime)
+		}
+		if !f.Type().IsEmbed() || f.Type().Embed().FullyQualifiedName() != ".google.protobuf."+protoTypeTimestamp {
+			p.Failf("the %s field of %s exposing its soft delete mark is not a google.protobuf.Timestamp", softDeleteTime, ormable.Name)
+		}
+	}
+	ormable.Fields[fieldName] = field
+	ormable.FieldsOrder = append(ormable.FieldsOrder, fieldName)
+}
+
+// softDeleteField returns the name and the field of the soft delete mark of an
+// ormable type, nil if its rows are not soft deleted
+func softDeleteField(ormable *OrmableType) (string, *Field) {
+	for _, fieldName := range ormable.FieldsOrder {
+		if field := ormable.Fields[fieldName]; field.SoftDelete != 0 {
+			return fieldName, field
+		}
+	}
+	return "", nil
+}
+
+// generateSoftDeleteConversion outputs the conversion of the deletion time of
+// a soft deleted row to or from its mark
+func (p *OrmPlugin) generateSoftDeleteConversion(message pgs.Message, field pgs.Field, toORM bool) {
+	fieldName := generator.CamelCase(string(field.Name()))
+	markName, mark := softDeleteField(p.getOrmable(message))
+	p.UsingGoImports(stdTimeImport)
+	if toORM {
+		p.P(`if m.Get`, fieldName, `() != nil {`)
+		p.P(`var t time.Time`)
+		p.P(`if t, err = `, p.Import(ptypesImport), `.Timestamp(m.`, fieldName, `); err != nil {`)
+		p.P(`return to, err`)
+		p.P(`}`)
+		if mark.SoftDelete == gorm.SoftDeleteMode_DELETED_AT {
+			p.P(`to.`, markName, ` = `, mark.Type, `{Time: t, Valid: true}`)
+		} else {
+			p.P(`to.`, markName, ` = `, mark.Type, `(t.Unix())`)
+		}
+		p.P(`}`)
+		return
+	}
+	if mark.SoftDelete == gorm.SoftDeleteMode_DELETED_AT {
+		p.P(`if m.`, markName, `.Valid {`)
+		p.P(`if to.`, fieldName, `, err = `, p.Import(ptypesImport), `.TimestampProto(m.`, markName, `.Time); err != nil {`)
+	} else {
+		p.P(`if m.`, markName, ` != 0 {`)
+		p.P(`if to.`, fieldName, `, err = `, p.Import(ptypesImport), `.TimestampProto(time.Unix(int64(m.`, markName, `), 0)); err != nil {`)
+	}
+	p.P(`return to, err`)
+	p.P(`}`)
+	p.P(`}`)
+}
+
+// generateScopeDeleted outputs the statement including the soft deleted rows
+// in the queries of a handler if its context asks for them
+func (p *OrmPlugin) generateScopeDeleted(ormable *OrmableType) {
+	if _, mark := softDeleteField(ormable); mark != nil {
+		p.P(`db = `, p.Import(tgormImport), `.ScopeDeleted(ctx, db)`)
+	}
+}
+
+// generateKeepDeleted outputs the lookup of the stored row an update
+// overwrites. A soft deleted row is not found unless the context includes
+// the deleted rows, and the update keeps its soft delete mark, which only the
+// delete handler sets.
+func (p *OrmPlugin) generateKeepDeleted(ormable *OrmableType) {
+	markName, mark := softDeleteField(ormable)
+	if mark == nil {
+		return
+	}
+	p.generateScopeDeleted(ormable)
+	p.P(`stored := `, ormable.Name, `{}`)
+	p.P(`if err = db.Where(&`, p.keyLiteral(ormable), `).First(&stored).Error; err != nil {`)
+	p.P(`return nil, err`)
+	p.P(`}`)
+	p.P(`ormObj.`, markName, ` = stored.`, markName)
+}
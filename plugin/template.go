@@ -3,9 +3,6 @@ package plugin
 const headerTpl = `package {{ package . }}
 
 import (
-	"context"
-	"time"
-
 	{{ generatedImports }}
 )
 
@@ -10,12 +10,6 @@ import (
 	pgs "github.com/lyft/protoc-gen-star"
 )
 
-func (p *OrmPlugin) getMsgName(o pgs.Message) string {
-	fqTypeName := o.FullyQualifiedName()
-	a := strings.Split(fqTypeName, ".")
-	return a[len(a)-1]
-}
-
 // retrieves the GormMessageOptions from a message
 func getMessageOptions(message pgs.Message) *gorm.GormMessageOptions {
 	if message.Descriptor().Options == nil {
@@ -39,35 +33,73 @@ func getFieldOptions(field pgs.Field) *gorm.GormFieldOptions {
 	return nil
 }
 
-// func getServiceOptions(service *descriptor.ServiceDescriptorProto) *gorm.AutoServerOptions {
-// 	if service.Options == nil {
-// 		return nil
-// 	}
-// 	v, err := proto.GetExtension(service.Options, gorm.E_Server)
-// 	if err != nil {
-// 		return nil
-// 	}
-// 	opts, ok := v.(*gorm.AutoServerOptions)
-// 	if !ok {
-// 		return nil
-// 	}
-// 	return opts
-// }
+// retrieves the AutoServerOptions from a service
+func getServiceOptions(service pgs.Service) *gorm.AutoServerOptions {
+	if service.Descriptor().Options == nil {
+		return nil
+	}
+	res := proto.GetExtension(service.Descriptor().Options, gorm.E_Server)
+	if converted, ok := res.(*gorm.AutoServerOptions); ok {
+		return converted
+	}
+	return nil
+}
+
+// retrieves the MethodOptions from a method
+func getMethodOptions(method pgs.Method) *gorm.MethodOptions {
+	if method.Descriptor().Options == nil {
+		return nil
+	}
+	res := proto.GetExtension(method.Descriptor().Options, gorm.E_Method)
+	if converted, ok := res.(*gorm.MethodOptions); ok {
+		return converted
+	}
+	return nil
+}
 
-// func getMethodOptions(method *descriptor.MethodDescriptorProto) *gorm.MethodOptions {
-// 	if method.Options == nil {
-// 		return nil
-// 	}
-// 	v, err := proto.GetExtension(method.Options, gorm.E_Method)
-// 	if err != nil {
-// 		return nil
-// 	}
-// 	opts, ok := v.(*gorm.MethodOptions)
-// 	if !ok {
-// 		return nil
-// 	}
-// 	return opts
-// }
+// retrieves the GormEnumOptions from an enum
+func getEnumOptions(enum pgs.Enum) *gorm.GormEnumOptions {
+	if enum.Descriptor().Options == nil {
+		return nil
+	}
+	res := proto.GetExtension(enum.Descriptor().Options, gorm.E_Enum)
+	if converted, ok := res.(*gorm.GormEnumOptions); ok {
+		return converted
+	}
+	return nil
+}
+
+// retrieves the GormFileOptions from a file
+func getFileOptions(file pgs.File) *gorm.GormFileOptions {
+	if file.Descriptor().Options == nil {
+		return nil
+	}
+	res := proto.GetExtension(file.Descriptor().Options, gorm.E_FileOpts)
+	if converted, ok := res.(*gorm.GormFileOptions); ok {
+		return converted
+	}
+	return nil
+}
+
+// defaultTag returns the tag the options of its file give to a field by its
+// name, nil if there is none
+func defaultTag(field pgs.Field) *gorm.GormTag {
+	for _, def := range getFileOptions(field.File()).GetDefaultTags() {
+		if def.GetField() == field.Name().String() {
+			return def.GetTag()
+		}
+	}
+	return nil
+}
+
+// mergeTags returns a copy of a default tag overridden by the values set in tag
+func mergeTags(def *gorm.GormTag, tag *gorm.GormTag) *gorm.GormTag {
+	merged := proto.Clone(def).(*gorm.GormTag)
+	if tag != nil {
+		proto.Merge(merged, tag)
+	}
+	return merged
+}
 
 func isSpecialType(typeName string) bool {
 	parts := strings.Split(typeName, ".")
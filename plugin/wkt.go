@@ -0,0 +1,85 @@
+package plugin
+
+import (
+	"strings"
+
+	pgs "github.com/lyft/protoc-gen-star"
+)
+
+const (
+	wktDuration   = "Duration"
+	wktBytesValue = "BytesValue"
+	wktAny        = "Any"
+	wktValue      = "Value"
+	wktListValue  = "ListValue"
+	wktFieldMask  = "FieldMask"
+)
+
+// wktName returns the name of the google.protobuf type of a message field if
+// it is one of the well known types handled here, "" otherwise. The full name
+// is checked since types like Value are commonly declared in user protos too.
+func wktName(field pgs.Field) string {
+	if !field.Type().IsEmbed() {
+		return ""
+	}
+	name := field.Type().Embed().FullyQualifiedName()
+	if !strings.HasPrefix(name, ".google.protobuf.") {
+		return ""
+	}
+	switch name = strings.TrimPrefix(name, ".google.protobuf."); name {
+	case wktDuration, wktBytesValue, wktAny, wktValue, wktListValue, wktFieldMask:
+		return name
+	}
+	return ""
+}
+
+// wktMapping returns the ORM representation of a well known type: Duration is
+// stored as nanoseconds, BytesValue in the binary column of the engine and the
+// other types as JSON documents
+func (p *OrmPlugin) wktMapping(wkt string) typeMapping {
+	switch wkt {
+	case wktDuration:
+		return typeMapping{goType: "*Duration", pkg: stdTimeImport}
+	case wktBytesValue:
+		return typeMapping{goType: "[]byte", column: p.dialect.binaryColumn}
+	}
+	return p.dialect.document
+}
+
+// generateWKTConversion outputs the conversion of a well known type field from
+// the src expression to the dst one
+func (p *OrmPlugin) generateWKTConversion(field pgs.Field, wkt string, src string, dst string, toORM bool) {
+	switch wkt {
+	case wktDuration:
+		p.P(`if `, src, ` != nil {`)
+		if toORM {
+			p.P(`d, err := `, p.Import(ptypesImport), `.Duration(`, src, `)`)
+			p.P(`if err != nil {`)
+			p.P(`return to, err`)
+			p.P(`}`)
+			p.P(dst, ` = &d`)
+		} else {
+			p.P(dst, ` = `, p.Import(ptypesImport), `.DurationProto(*`, src, `)`)
+		}
+		p.P(`}`)
+	case wktBytesValue:
+		// An empty value is stored as an empty blob to keep it apart from NULL
+		p.P(`if `, src, ` != nil {`)
+		if toORM {
+			p.P(dst, ` = append([]byte{}, `, src, `.Value...)`)
+		} else {
+			p.P(dst, ` = &`, p.messageTypeName(field.Type().Embed()), `{Value: `, src, `}`)
+		}
+		p.P(`}`)
+	case wktAny:
+		if toORM {
+			p.P(`if `, dst, `, err = `, p.Import(gtypesImport), `.MarshalAny(`, src, `); err != nil {`)
+		} else {
+			p.P(`if `, dst, `, err = `, p.Import(gtypesImport), `.UnmarshalAny(`, src, `); err != nil {`)
+		}
+		p.P(`return to, err`)
+		p.P(`}`)
+	default:
+		p.generateSerializedConversion(field, src, dst, toORM)
+	}
+}
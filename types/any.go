@@ -0,0 +1,36 @@
+package types
+
+import (
+	"encoding/json"
+
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// anyDocument is the JSON document storing a google.protobuf.Any
+type anyDocument struct {
+	TypeURL string `json:"type_url"`
+	Value   []byte `json:"value"`
+}
+
+// MarshalAny encodes a google.protobuf.Any as a JSON document holding its type
+// URL and its base64 encoded payload, so that the payload type does not need
+// to be linked into the program as protojson would require
+func MarshalAny(a *anypb.Any) (JSON, error) {
+	if a == nil {
+		return nil, nil
+	}
+	return json.Marshal(anyDocument{TypeURL: a.GetTypeUrl(), Value: a.GetValue()})
+}
+
+// UnmarshalAny decodes a document written by MarshalAny, NULL documents are
+// decoded as nil
+func UnmarshalAny(doc JSON) (*anypb.Any, error) {
+	if doc == nil {
+		return nil, nil
+	}
+	var d anyDocument
+	if err := json.Unmarshal(doc, &d); err != nil {
+		return nil, err
+	}
+	return &anypb.Any{TypeUrl: d.TypeURL, Value: d.Value}, nil
+}
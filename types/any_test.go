@@ -0,0 +1,46 @@
+package types
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func TestAnyRoundTrip(t *testing.T) {
+	cases := []struct {
+		name string
+		any  *anypb.Any
+		doc  string
+	}{
+		{"nil", nil, ""},
+		{"empty", &anypb.Any{}, `{"type_url":"","value":null}`},
+		{"payload", &anypb.Any{TypeUrl: "type.googleapis.com/demo.Plain", Value: []byte{0x0a, 0x01, 0x78}},
+			`{"type_url":"type.googleapis.com/demo.Plain","value":"CgF4"}`},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			doc, err := MarshalAny(c.any)
+			if err != nil {
+				t.Fatalf("Got unexpected error: %s", err)
+			}
+			if string(doc) != c.doc {
+				t.Errorf("Expected document %s, got %s", c.doc, doc)
+			}
+			decoded, err := UnmarshalAny(doc)
+			if err != nil {
+				t.Fatalf("Got unexpected error: %s", err)
+			}
+			if (decoded == nil) != (c.any == nil) || !proto.Equal(decoded, c.any) {
+				t.Errorf("Expected decoded value %v, got %v", c.any, decoded)
+			}
+		})
+	}
+}
+
+func TestUnmarshalAnyInvalid(t *testing.T) {
+	if _, err := UnmarshalAny(JSON(`[]`)); err == nil {
+		t.Error("Expected an error for a non object document")
+	}
+}
@@ -0,0 +1,153 @@
+package types
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// Int32Array is a []int32 stored as a Postgres array
+type Int32Array []int32
+
+// Value implements driver.Valuer
+func (a Int32Array) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	wide := make(pq.Int64Array, len(a))
+	for i, v := range a {
+		wide[i] = int64(v)
+	}
+	return wide.Value()
+}
+
+// Scan implements sql.Scanner
+func (a *Int32Array) Scan(src interface{}) error {
+	var wide pq.Int64Array
+	if err := wide.Scan(src); err != nil {
+		return err
+	}
+	if wide == nil {
+		*a = nil
+		return nil
+	}
+	narrow := make(Int32Array, len(wide))
+	for i, v := range wide {
+		if v < math.MinInt32 || v > math.MaxInt32 {
+			return fmt.Errorf("array element %d overflows int32", v)
+		}
+		narrow[i] = int32(v)
+	}
+	*a = narrow
+	return nil
+}
+
+// Uint32Array is a []uint32 stored as a Postgres array
+type Uint32Array []uint32
+
+// Value implements driver.Valuer
+func (a Uint32Array) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	wide := make(pq.Int64Array, len(a))
+	for i, v := range a {
+		wide[i] = int64(v)
+	}
+	return wide.Value()
+}
+
+// Scan implements sql.Scanner
+func (a *Uint32Array) Scan(src interface{}) error {
+	var wide pq.Int64Array
+	if err := wide.Scan(src); err != nil {
+		return err
+	}
+	if wide == nil {
+		*a = nil
+		return nil
+	}
+	narrow := make(Uint32Array, len(wide))
+	for i, v := range wide {
+		if v < 0 || v > math.MaxUint32 {
+			return fmt.Errorf("array element %d overflows uint32", v)
+		}
+		narrow[i] = uint32(v)
+	}
+	*a = narrow
+	return nil
+}
+
+// Uint64Array is a []uint64 stored as a Postgres numeric array, as bigint
+// cannot hold the upper half of the range
+type Uint64Array []uint64
+
+// Value implements driver.Valuer
+func (a Uint64Array) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	elems := make([]string, len(a))
+	for i, v := range a {
+		elems[i] = strconv.FormatUint(v, 10)
+	}
+	return "{" + strings.Join(elems, ",") + "}", nil
+}
+
+// Scan implements sql.Scanner
+func (a *Uint64Array) Scan(src interface{}) error {
+	var elems pq.StringArray
+	if err := elems.Scan(src); err != nil {
+		return err
+	}
+	if elems == nil {
+		*a = nil
+		return nil
+	}
+	values := make(Uint64Array, len(elems))
+	for i, v := range elems {
+		var err error
+		if values[i], err = strconv.ParseUint(v, 10, 64); err != nil {
+			return err
+		}
+	}
+	*a = values
+	return nil
+}
+
+// Float32Array is a []float32 stored as a Postgres array
+type Float32Array []float32
+
+// Value implements driver.Valuer
+func (a Float32Array) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	elems := make([]string, len(a))
+	for i, v := range a {
+		elems[i] = strconv.FormatFloat(float64(v), 'g', -1, 32)
+	}
+	return "{" + strings.Join(elems, ",") + "}", nil
+}
+
+// Scan implements sql.Scanner
+func (a *Float32Array) Scan(src interface{}) error {
+	var wide pq.Float64Array
+	if err := wide.Scan(src); err != nil {
+		return err
+	}
+	if wide == nil {
+		*a = nil
+		return nil
+	}
+	narrow := make(Float32Array, len(wide))
+	for i, v := range wide {
+		narrow[i] = float32(v)
+	}
+	*a = narrow
+	return nil
+}
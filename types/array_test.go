@@ -0,0 +1,56 @@
+package types
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestArrays(t *testing.T) {
+	cases := []struct {
+		name   string
+		array  driver.Valuer
+		stored driver.Value
+		dest   sql.Scanner
+	}{
+		{"int32", Int32Array{math.MinInt32, 0, math.MaxInt32}, `{-2147483648,0,2147483647}`, &Int32Array{}},
+		{"uint32", Uint32Array{0, math.MaxUint32}, `{0,4294967295}`, &Uint32Array{}},
+		{"uint64", Uint64Array{1, math.MaxUint64}, `{1,18446744073709551615}`, &Uint64Array{}},
+		{"float32", Float32Array{0.1, -2.5}, `{0.1,-2.5}`, &Float32Array{}},
+		{"empty", Int32Array{}, `{}`, &Int32Array{}},
+		{"nil", Uint64Array(nil), nil, &Uint64Array{1}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			stored, err := c.array.Value()
+			if err != nil {
+				t.Fatalf("Got unexpected error: %s", err)
+			}
+			if stored != c.stored {
+				t.Errorf("Expected stored value %#v, got %#v", c.stored, stored)
+			}
+			if err := c.dest.Scan(stored); err != nil {
+				t.Fatalf("Got unexpected error: %s", err)
+			}
+			if scanned := reflect.ValueOf(c.dest).Elem().Interface(); !reflect.DeepEqual(scanned, c.array) {
+				t.Errorf("Expected scanned value %#v, got %#v", c.array, scanned)
+			}
+		})
+	}
+
+	for _, c := range []struct {
+		dest sql.Scanner
+		src  string
+	}{
+		{&Int32Array{}, `{2147483648}`},
+		{&Uint32Array{}, `{-1}`},
+		{&Uint64Array{}, `{-1}`},
+	} {
+		if err := c.dest.Scan(c.src); err == nil {
+			t.Errorf("Expected error scanning %s into %T but didn't get any", c.src, c.dest)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// EnumName returns the stored name of an enum value given the _name map of
+// the enum. With a prefix, e.g. "STATUS_", the name is stored without it and
+// in lower case. Unknown values are an error rather than an empty name.
+func EnumName(names map[int32]string, value int32, prefix string) (string, error) {
+	name, ok := names[value]
+	if !ok {
+		return "", fmt.Errorf("unknown enum value %d", value)
+	}
+	if prefix != "" {
+		name = strings.ToLower(strings.TrimPrefix(name, prefix))
+	}
+	return name, nil
+}
+
+// EnumValue returns the value of a name stored by EnumName given the _value
+// map of the enum. Unknown names are an error rather than the zero value.
+func EnumValue(values map[string]int32, name string, prefix string) (int32, error) {
+	if prefix != "" {
+		name = strings.ToUpper(name)
+		if value, ok := values[prefix+name]; ok {
+			return value, nil
+		}
+	}
+	value, ok := values[name]
+	if !ok {
+		return 0, fmt.Errorf("unknown enum name %q", name)
+	}
+	return value, nil
+}
@@ -0,0 +1,53 @@
+package types
+
+import "testing"
+
+var (
+	statusName  = map[int32]string{0: "UNKNOWN", 1: "STATUS_ACTIVE", 2: "STATUS_PAUSED"}
+	statusValue = map[string]int32{"UNKNOWN": 0, "STATUS_ACTIVE": 1, "STATUS_PAUSED": 2}
+)
+
+func TestEnumRoundTrip(t *testing.T) {
+	cases := []struct {
+		value  int32
+		prefix string
+		name   string
+	}{
+		{0, "", "UNKNOWN"},
+		{1, "", "STATUS_ACTIVE"},
+		{0, "STATUS_", "unknown"},
+		{1, "STATUS_", "active"},
+		{2, "STATUS_", "paused"},
+	}
+
+	for _, c := range cases {
+		name, err := EnumName(statusName, c.value, c.prefix)
+		if err != nil {
+			t.Fatalf("Got unexpected error: %s", err)
+		}
+		if name != c.name {
+			t.Errorf("Expected name %q for %d, got %q", c.name, c.value, name)
+		}
+		value, err := EnumValue(statusValue, name, c.prefix)
+		if err != nil {
+			t.Fatalf("Got unexpected error: %s", err)
+		}
+		if value != c.value {
+			t.Errorf("Expected value %d for %q, got %d", c.value, name, value)
+		}
+	}
+}
+
+func TestEnumUnknown(t *testing.T) {
+	if _, err := EnumName(statusName, 7, ""); err == nil {
+		t.Error("Expected an error for an unknown value")
+	}
+	for _, name := range []string{"", "PAUSED", "active", "STATUS_MISSING"} {
+		if _, err := EnumValue(statusValue, name, ""); err == nil {
+			t.Errorf("Expected an error for unknown name %q", name)
+		}
+	}
+	if _, err := EnumValue(statusValue, "missing", "STATUS_"); err == nil {
+		t.Error("Expected an error for an unknown trimmed name")
+	}
+}
@@ -0,0 +1,217 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// JSON is a raw JSON document for engines without a Postgres style jsonb
+// type. It is written as a string so that MySQL accepts it in json columns.
+type JSON []byte
+
+// Value implements driver.Valuer
+func (j JSON) Value() (driver.Value, error) {
+	if j == nil {
+		return nil, nil
+	}
+	return string(j), nil
+}
+
+// Scan implements sql.Scanner
+func (j *JSON) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*j = nil
+	case []byte:
+		*j = append((*j)[0:0], v...)
+	case string:
+		*j = JSON(v)
+	default:
+		return fmt.Errorf("cannot scan %T into JSON", value)
+	}
+	return nil
+}
+
+// MarshalJSON returns the document itself
+func (j JSON) MarshalJSON() ([]byte, error) {
+	if j == nil {
+		return []byte("null"), nil
+	}
+	return j, nil
+}
+
+// UnmarshalJSON stores a copy of the document
+func (j *JSON) UnmarshalJSON(data []byte) error {
+	*j = append((*j)[0:0], data...)
+	return nil
+}
+
+// JSONBoolArray is a []bool stored as a JSON array
+type JSONBoolArray []bool
+
+// Value implements driver.Valuer
+func (a JSONBoolArray) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	return jsonArrayValue(a)
+}
+
+// Scan implements sql.Scanner
+func (a *JSONBoolArray) Scan(value interface{}) error {
+	return jsonArrayScan(value, a)
+}
+
+// JSONInt64Array is a []int64 stored as a JSON array
+type JSONInt64Array []int64
+
+// Value implements driver.Valuer
+func (a JSONInt64Array) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	return jsonArrayValue(a)
+}
+
+// Scan implements sql.Scanner
+func (a *JSONInt64Array) Scan(value interface{}) error {
+	return jsonArrayScan(value, a)
+}
+
+// JSONFloat64Array is a []float64 stored as a JSON array
+type JSONFloat64Array []float64
+
+// Value implements driver.Valuer
+func (a JSONFloat64Array) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	return jsonArrayValue(a)
+}
+
+// Scan implements sql.Scanner
+func (a *JSONFloat64Array) Scan(value interface{}) error {
+	return jsonArrayScan(value, a)
+}
+
+// JSONStringArray is a []string stored as a JSON array
+type JSONStringArray []string
+
+// Value implements driver.Valuer
+func (a JSONStringArray) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	return jsonArrayValue(a)
+}
+
+// Scan implements sql.Scanner
+func (a *JSONStringArray) Scan(value interface{}) error {
+	return jsonArrayScan(value, a)
+}
+
+// JSONInt32Array is a []int32 stored as a JSON array
+type JSONInt32Array []int32
+
+// Value implements driver.Valuer
+func (a JSONInt32Array) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	return jsonArrayValue(a)
+}
+
+// Scan implements sql.Scanner
+func (a *JSONInt32Array) Scan(value interface{}) error {
+	return jsonArrayScan(value, a)
+}
+
+// JSONUint32Array is a []uint32 stored as a JSON array
+type JSONUint32Array []uint32
+
+// Value implements driver.Valuer
+func (a JSONUint32Array) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	return jsonArrayValue(a)
+}
+
+// Scan implements sql.Scanner
+func (a *JSONUint32Array) Scan(value interface{}) error {
+	return jsonArrayScan(value, a)
+}
+
+// JSONUint64Array is a []uint64 stored as a JSON array
+type JSONUint64Array []uint64
+
+// Value implements driver.Valuer
+func (a JSONUint64Array) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	return jsonArrayValue(a)
+}
+
+// Scan implements sql.Scanner
+func (a *JSONUint64Array) Scan(value interface{}) error {
+	return jsonArrayScan(value, a)
+}
+
+// JSONFloat32Array is a []float32 stored as a JSON array
+type JSONFloat32Array []float32
+
+// Value implements driver.Valuer
+func (a JSONFloat32Array) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	return jsonArrayValue(a)
+}
+
+// Scan implements sql.Scanner
+func (a *JSONFloat32Array) Scan(value interface{}) error {
+	return jsonArrayScan(value, a)
+}
+
+// JSONBytesArray is a [][]byte stored as a JSON array of base64 strings
+type JSONBytesArray [][]byte
+
+// Value implements driver.Valuer
+func (a JSONBytesArray) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	return jsonArrayValue(a)
+}
+
+// Scan implements sql.Scanner
+func (a *JSONBytesArray) Scan(value interface{}) error {
+	return jsonArrayScan(value, a)
+}
+
+func jsonArrayValue(a interface{}) (driver.Value, error) {
+	data, err := json.Marshal(a)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// jsonArrayScan decodes a JSON array into dest, a pointer to a slice which is
+// set to nil for NULL values
+func jsonArrayScan(value interface{}, dest interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		data = []byte("null")
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into %T", value, dest)
+	}
+	return json.Unmarshal(data, dest)
+}
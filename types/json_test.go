@@ -0,0 +1,92 @@
+package types
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestJSONValueAndScan(t *testing.T) {
+	cases := []struct {
+		name   string
+		json   JSON
+		stored interface{}
+	}{
+		{"null", nil, nil},
+		{"object", JSON(`{"key": "value"}`), `{"key": "value"}`},
+		{"empty array", JSON(`[]`), `[]`},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			v, err := c.json.Value()
+			if err != nil {
+				t.Fatalf("Got unexpected error: %s", err)
+			}
+			if v != c.stored {
+				t.Errorf("Expected stored value %#v, got %#v", c.stored, v)
+			}
+			for _, src := range []interface{}{v, []byte(c.json)} {
+				if src == nil && c.stored != nil {
+					continue
+				}
+				var scanned JSON
+				if err := scanned.Scan(src); err != nil {
+					t.Fatalf("Got unexpected error: %s", err)
+				}
+				if string(scanned) != string(c.json) {
+					t.Errorf("Expected scanned value %q, got %q", c.json, scanned)
+				}
+			}
+		})
+	}
+
+	var j JSON
+	if err := j.Scan(42); err == nil {
+		t.Error("Expected error but didn't get any")
+	}
+}
+
+func TestJSONArrays(t *testing.T) {
+	cases := []struct {
+		name   string
+		array  driver.Valuer
+		stored driver.Value
+		dest   sql.Scanner
+	}{
+		{"bool", JSONBoolArray{true, false}, `[true,false]`, &JSONBoolArray{}},
+		{"int64", JSONInt64Array{1, -2, 3}, `[1,-2,3]`, &JSONInt64Array{}},
+		{"float64", JSONFloat64Array{1.5, 2}, `[1.5,2]`, &JSONFloat64Array{}},
+		{"string", JSONStringArray{"a", `b"c`}, `["a","b\"c"]`, &JSONStringArray{}},
+		{"int32", JSONInt32Array{math.MinInt32, 7}, `[-2147483648,7]`, &JSONInt32Array{}},
+		{"uint32", JSONUint32Array{math.MaxUint32}, `[4294967295]`, &JSONUint32Array{}},
+		{"uint64", JSONUint64Array{math.MaxUint64}, `[18446744073709551615]`, &JSONUint64Array{}},
+		{"float32", JSONFloat32Array{0.1}, `[0.1]`, &JSONFloat32Array{}},
+		{"bytes", JSONBytesArray{[]byte("hi"), {}}, `["aGk=",""]`, &JSONBytesArray{}},
+		{"nil", JSONStringArray(nil), nil, &JSONStringArray{"left", "over"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			stored, err := c.array.Value()
+			if err != nil {
+				t.Fatalf("Got unexpected error: %s", err)
+			}
+			if stored != c.stored {
+				t.Errorf("Expected stored value %#v, got %#v", c.stored, stored)
+			}
+			if err := c.dest.Scan(stored); err != nil {
+				t.Fatalf("Got unexpected error: %s", err)
+			}
+			if scanned := reflect.ValueOf(c.dest).Elem().Interface(); !reflect.DeepEqual(scanned, c.array) {
+				t.Errorf("Expected scanned value %#v, got %#v", c.array, scanned)
+			}
+		})
+	}
+
+	if err := (&JSONInt64Array{}).Scan(`["a"]`); err == nil {
+		t.Error("Expected error but didn't get any")
+	}
+}
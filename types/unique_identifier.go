@@ -0,0 +1,10 @@
+package types
+
+// ReorderUniqueIdentifier converts the bytes of a UUID between the RFC 4122
+// order and the mixed-endian order of a SQL Server uniqueidentifier, whose
+// first three groups are little-endian. The conversion is its own inverse.
+func ReorderUniqueIdentifier(u *[16]byte) {
+	u[0], u[1], u[2], u[3] = u[3], u[2], u[1], u[0]
+	u[4], u[5] = u[5], u[4]
+	u[6], u[7] = u[7], u[6]
+}
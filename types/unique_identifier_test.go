@@ -0,0 +1,21 @@
+package types
+
+import (
+	"testing"
+)
+
+func TestReorderUniqueIdentifier(t *testing.T) {
+	// 6f9619ff-8b86-d011-b42d-00c04fc964ff as read from a uniqueidentifier
+	stored := [16]byte{0xff, 0x19, 0x96, 0x6f, 0x86, 0x8b, 0x11, 0xd0, 0xb4, 0x2d, 0x00, 0xc0, 0x4f, 0xc9, 0x64, 0xff}
+	expected := [16]byte{0x6f, 0x96, 0x19, 0xff, 0x8b, 0x86, 0xd0, 0x11, 0xb4, 0x2d, 0x00, 0xc0, 0x4f, 0xc9, 0x64, 0xff}
+
+	u := stored
+	ReorderUniqueIdentifier(&u)
+	if u != expected {
+		t.Errorf("Expected %x, got %x", expected, u)
+	}
+	ReorderUniqueIdentifier(&u)
+	if u != stored {
+		t.Errorf("Expected the reordering to be undone, got %x", u)
+	}
+}